@@ -17,11 +17,15 @@ func main() {
 	runCmd := flag.NewFlagSet("run", flag.ExitOnError)
 	createCmd := flag.NewFlagSet("create", flag.ExitOnError)
 	inspectCmd := flag.NewFlagSet("inspect", flag.ExitOnError)
+	checkCmd := flag.NewFlagSet("check", flag.ExitOnError)
 
 	// Add verbosity flags
 	buildVerbose := buildCmd.Bool("verbose", false, "Enable verbose output")
 	runVerbose := runCmd.Bool("verbose", false, "Enable verbose output")
+	runStrict := runCmd.Bool("strict", false, "Make out-of-range index reads errors")
+	runNoFakeNews := runCmd.Bool("no-fake-news", false, "Suppress warnings")
 	buildNoFakeNews := buildCmd.Bool("no-fake-news", false, "Suppress warnings")
+	checkNoFakeNews := checkCmd.Bool("no-fake-news", false, "Suppress warnings")
 
 	// Check for correct number of arguments
 	if len(os.Args) < 2 {
@@ -36,13 +40,16 @@ func main() {
 		cmd.BuildTrump(buildCmd.Args(), *buildVerbose, *buildNoFakeNews)
 	case "run":
 		runCmd.Parse(os.Args[2:])
-		cmd.RunTrump(runCmd.Args(), *runVerbose)
+		cmd.RunTrump(runCmd.Args(), *runVerbose, *runStrict, *runNoFakeNews)
 	case "create":
 		createCmd.Parse(os.Args[2:])
 		cmd.CreateTrump(createCmd.Args())
 	case "inspect":
 		inspectCmd.Parse(os.Args[2:])
 		cmd.InspectTrump(inspectCmd.Args())
+	case "check":
+		checkCmd.Parse(os.Args[2:])
+		cmd.CheckTrump(checkCmd.Args(), *checkNoFakeNews)
 	default:
 		fmt.Printf("Unknown command: %s\n", os.Args[1])
 		cmd.PrintUsage()
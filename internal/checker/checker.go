@@ -0,0 +1,499 @@
+// file: internal/checker/checker.go
+// description: Static type checking for the TRUMP programming language
+
+package checker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AndrewDonelson/trumplang/internal/errors"
+	"github.com/AndrewDonelson/trumplang/internal/interpreter"
+	"github.com/AndrewDonelson/trumplang/internal/lexer/token"
+	"github.com/AndrewDonelson/trumplang/internal/parser"
+)
+
+// symbol is what the checker knows about a name
+type symbol struct {
+	typ       string                  // Empty when the type is unknown
+	annotated bool                    // Whether typ comes from a type annotation
+	fn        *parser.FunctionLiteral // Set when the name always holds this function
+}
+
+// scope holds the names declared in one scope of the running program.
+// Blocks share the scope of the file, function or loop they appear in, as
+// they do when the program runs, except that each branch of an IF is
+// checked in a scope of its own and what its names are known to hold is
+// combined afterwards, since only one branch runs.
+type scope struct {
+	names map[string]symbol
+	outer *scope
+}
+
+func newScope(outer *scope) *scope {
+	return &scope{names: make(map[string]symbol), outer: outer}
+}
+
+// Find what is known about a name
+func (s *scope) lookup(name string) (symbol, bool) {
+	for ; s != nil; s = s.outer {
+		if sym, ok := s.names[name]; ok {
+			return sym, true
+		}
+	}
+	return symbol{}, false
+}
+
+// Checker infers the types of expressions where it can and reports
+// operations, assignments, arguments and return values whose types can
+// never work
+type Checker struct {
+	errors    []string
+	scope     *scope
+	assigned  map[string]bool           // Names assigned to anywhere, whose types may change
+	functions []*parser.FunctionLiteral // Functions being checked, innermost last
+}
+
+// Check type checks a parsed program, returning the mismatches it finds.
+// Unannotated code stays dynamically typed, so it is only checked where
+// the types involved can be inferred.
+func Check(program *parser.Program) []string {
+	c := &Checker{
+		scope:    newScope(nil),
+		assigned: assignedNames(program),
+	}
+	c.statements(program.Statements)
+	return c.errors
+}
+
+// Collect the names that are assigned to anywhere in a program
+func assignedNames(program *parser.Program) map[string]bool {
+	names := map[string]bool{}
+	parser.Walk(program, func(node parser.Node) bool {
+		if assign, ok := node.(*parser.AssignExpression); ok {
+			if name, ok := assign.Target.(*parser.Identifier); ok {
+				names[name.Value] = true
+			}
+		}
+		return true
+	})
+	return names
+}
+
+// Report a type error at the given token
+func (c *Checker) errorAt(tok token.Token, format string, args ...interface{}) {
+	err := errors.NewTrumpError(errors.TYPE_MISMATCH, fmt.Sprintf(format, args...), tok.Line, tok.Column)
+	c.errors = append(c.errors, errors.InFile(tok.File, err))
+}
+
+// Declare a name in the current scope. Without an annotation its type is
+// only trusted if nothing can assign it another one.
+func (c *Checker) declare(name, annotation, inferred string, fn *parser.FunctionLiteral, constant bool) {
+	switch {
+	case annotation != "":
+		c.scope.names[name] = symbol{typ: annotation, annotated: true}
+	case constant || !c.assigned[name]:
+		c.scope.names[name] = symbol{typ: inferred, fn: fn}
+	default:
+		c.scope.names[name] = symbol{}
+	}
+}
+
+// Run a check in a new scope
+func (c *Checker) enclosed(check func()) {
+	outer := c.scope
+	c.scope = newScope(outer)
+	check()
+	c.scope = outer
+}
+
+// Check the branches of an IF, each in its own scope, then declare the names
+// they declared in the current scope. Whichever branch runs declares them
+// there, so a name whose branches disagree about it has an unknown type.
+func (c *Checker) branches(blocks ...*parser.BlockStatement) {
+	outer := c.scope
+	declared := map[string]symbol{}
+	for _, block := range blocks {
+		c.scope = newScope(outer)
+		c.block(block)
+		for name, sym := range c.scope.names {
+			// Keep a type both branches agree on, but nothing more
+			if previous, ok := declared[name]; ok && previous != sym {
+				if previous.typ == sym.typ {
+					sym = symbol{typ: sym.typ}
+				} else {
+					sym = symbol{}
+				}
+			}
+			declared[name] = sym
+		}
+	}
+	c.scope = outer
+
+	for name, sym := range declared {
+		c.scope.names[name] = sym
+	}
+}
+
+// Check a list of statements, declaring its functions first since they
+// are hoisted when the program runs
+func (c *Checker) statements(statements []parser.Statement) {
+	for _, statement := range statements {
+		if export, ok := statement.(*parser.ExportStatement); ok {
+			statement = export.Declaration
+		}
+		if decl, ok := statement.(*parser.FunctionDeclaration); ok {
+			c.declare(decl.Name.Value, "", interpreter.FUNCTION_OBJ, decl.Function, decl.Constant())
+		}
+	}
+
+	for _, statement := range statements {
+		c.statement(statement)
+	}
+}
+
+func (c *Checker) block(block *parser.BlockStatement) {
+	if block != nil {
+		c.statements(block.Statements)
+	}
+}
+
+func (c *Checker) statement(statement parser.Statement) {
+	switch node := statement.(type) {
+	case *parser.LetStatement:
+		typ := c.expression(node.Value)
+		if node.Type != "" && !assignable(node.Type, typ) {
+			c.errorAt(node.Name.Token, "cannot assign %s to %s of type %s", typ, node.Name.Value, node.Type)
+		}
+		fn, _ := node.Value.(*parser.FunctionLiteral)
+		c.declare(node.Name.Value, node.Type, typ, fn, node.Constant())
+	case *parser.FunctionDeclaration:
+		c.function(node.Function)
+	case *parser.ExportStatement:
+		c.statement(node.Declaration)
+	case *parser.ImportStatement:
+		if node.Alias != nil {
+			c.scope.names[node.Alias.Value] = symbol{typ: interpreter.MODULE_OBJ}
+		}
+	case *parser.ReturnStatement:
+		typ := c.expression(node.ReturnValue)
+		if fn := c.enclosingFunction(); fn != nil && !fn.Generator && fn.ReturnType != "" && !assignable(fn.ReturnType, typ) {
+			c.errorAt(node.Token, "cannot return %s from a function returning %s", typ, fn.ReturnType)
+		}
+	case *parser.YieldStatement:
+		// A generator's return type annotation is the type of what it yields
+		typ := c.expression(node.Value)
+		if fn := c.enclosingFunction(); fn != nil && fn.ReturnType != "" && !assignable(fn.ReturnType, typ) {
+			c.errorAt(node.Token, "cannot yield %s from a generator yielding %s", typ, fn.ReturnType)
+		}
+	case *parser.ExpressionStatement:
+		c.expression(node.Expression)
+	case *parser.TweetStatement:
+		c.expression(node.Value)
+	case *parser.RallyStatement:
+		c.expression(node.Value)
+	case *parser.ExecutiveOrderStatement:
+		c.expression(node.Value)
+	case *parser.ThrowStatement:
+		c.expression(node.Value)
+	case *parser.BlockStatement:
+		c.block(node)
+	case *parser.IfStatement:
+		c.expression(node.Condition)
+		c.branches(node.Consequence, node.Alternative)
+	case *parser.WhileStatement:
+		c.expression(node.Condition)
+		c.block(node.Body)
+	case *parser.ForStatement:
+		c.enclosed(func() {
+			if node.Init != nil {
+				c.statement(node.Init)
+			}
+			c.expression(node.Condition)
+			if node.Update != nil {
+				c.statement(node.Update)
+			}
+			c.block(node.Body)
+		})
+	case *parser.ForEachStatement:
+		iterable := c.expression(node.Iterable)
+		c.enclosed(func() {
+			if node.Key != nil {
+				key := ""
+				if iterable != "" && iterable != interpreter.HASH_OBJ {
+					key = interpreter.INTEGER_OBJ
+				}
+				c.declare(node.Key.Value, "", key, nil, false)
+			}
+			value := ""
+			switch iterable {
+			case interpreter.STRING_OBJ:
+				value = interpreter.STRING_OBJ
+			case interpreter.RANGE_OBJ:
+				value = interpreter.INTEGER_OBJ
+			}
+			c.declare(node.Value.Value, "", value, nil, false)
+			c.block(node.Body)
+		})
+	case *parser.TryStatement:
+		c.block(node.Body)
+		if node.CatchParam != nil {
+			c.enclosed(func() {
+				c.scope.names[node.CatchParam.Value] = symbol{}
+				c.block(node.Catch)
+			})
+		} else {
+			c.block(node.Catch)
+		}
+		c.block(node.Finally)
+	}
+}
+
+// Check the body of a function in a scope holding its parameters
+func (c *Checker) function(fn *parser.FunctionLiteral) {
+	c.enclosed(func() {
+		for i, param := range fn.Parameters {
+			annotation := ""
+			if i < len(fn.ParameterTypes) {
+				annotation = fn.ParameterTypes[i]
+			}
+			c.declare(param.Value, annotation, "", nil, false)
+		}
+
+		c.functions = append(c.functions, fn)
+		c.block(fn.Body)
+		c.functions = c.functions[:len(c.functions)-1]
+	})
+}
+
+// The innermost function being checked, or nil at the top level
+func (c *Checker) enclosingFunction() *parser.FunctionLiteral {
+	if len(c.functions) == 0 {
+		return nil
+	}
+	return c.functions[len(c.functions)-1]
+}
+
+// Infer the type of an expression, checking it on the way. The empty
+// string means the type is not known until the program runs.
+func (c *Checker) expression(expression parser.Expression) string {
+	switch node := expression.(type) {
+	case *parser.IntegerLiteral:
+		return interpreter.INTEGER_OBJ
+	case *parser.BigIntegerLiteral:
+		return interpreter.BIG_INT_OBJ
+	case *parser.DecimalLiteral:
+		return interpreter.DECIMAL_OBJ
+	case *parser.FloatLiteral:
+		return interpreter.FLOAT_OBJ
+	case *parser.StringLiteral:
+		return interpreter.STRING_OBJ
+	case *parser.BooleanLiteral:
+		return interpreter.BOOLEAN_OBJ
+	case *parser.InterpolatedString:
+		for _, part := range node.Parts {
+			c.expression(part)
+		}
+		return interpreter.STRING_OBJ
+	case *parser.Placeholder:
+		return c.expression(node.Value)
+	case *parser.ArrayLiteral:
+		for _, el := range node.Elements {
+			c.expression(el)
+		}
+		return interpreter.ARRAY_OBJ
+	case *parser.HashLiteral:
+		for _, pair := range node.Pairs {
+			c.expression(pair.Key)
+			c.expression(pair.Value)
+		}
+		return interpreter.HASH_OBJ
+	case *parser.FunctionLiteral:
+		c.function(node)
+		return interpreter.FUNCTION_OBJ
+	case *parser.Identifier:
+		sym, _ := c.scope.lookup(node.Value)
+		return sym.typ
+	case *parser.IfExpression:
+		c.expression(node.Condition)
+		c.branches(node.Consequence, node.Alternative)
+		return ""
+	case *parser.ConditionalExpression:
+		c.expression(node.Condition)
+		consequence := c.expression(node.Consequence)
+		alternative := c.expression(node.Alternative)
+		if consequence == alternative {
+			return consequence
+		}
+		return ""
+	case *parser.RangeExpression:
+		for _, bound := range []parser.Expression{node.Start, node.End, node.Step} {
+			if bound == nil {
+				continue
+			}
+			if typ := c.expression(bound); typ != "" && typ != interpreter.INTEGER_OBJ {
+				c.errorAt(node.Token, "range bounds must be INTEGER, got %s", typ)
+			}
+		}
+		return interpreter.RANGE_OBJ
+	case *parser.PrefixExpression:
+		return c.prefixExpression(node)
+	case *parser.InfixExpression:
+		left := c.expression(node.Left)
+		right := c.expression(node.Right)
+		typ, problem := infixType(node.Operator, left, right)
+		if problem != "" {
+			c.errorAt(node.Token, "%s", problem)
+		}
+		return typ
+	case *parser.AssignExpression:
+		return c.assignExpression(node)
+	case *parser.CallExpression:
+		return c.callExpression(node)
+	case *parser.SpawnExpression:
+		c.callExpression(node.Call)
+		return interpreter.TASK_OBJ
+	case *parser.IndexExpression:
+		left := c.expression(node.Left)
+		c.expression(node.Index)
+		switch left {
+		case interpreter.STRING_OBJ:
+			return interpreter.STRING_OBJ
+		case interpreter.RANGE_OBJ:
+			return interpreter.INTEGER_OBJ
+		}
+		return ""
+	case *parser.SliceExpression:
+		left := c.expression(node.Left)
+		c.expression(node.Start)
+		c.expression(node.End)
+		if left == interpreter.STRING_OBJ || left == interpreter.ARRAY_OBJ {
+			return left
+		}
+		return ""
+	case *parser.MatchExpression:
+		c.expression(node.Subject)
+		for _, arm := range node.Arms {
+			c.matchArm(arm)
+		}
+		return ""
+	default:
+		return ""
+	}
+}
+
+func (c *Checker) prefixExpression(node *parser.PrefixExpression) string {
+	right := c.expression(node.Right)
+
+	switch {
+	case node.Operator == "!":
+		return interpreter.BOOLEAN_OBJ
+	case right == "":
+		return ""
+	case node.Operator == "-" && isNumeric(right):
+		return right
+	case node.Operator == "~" && isInteger(right):
+		return right
+	}
+
+	c.errorAt(node.Token, "unknown operator: %s%s", node.Operator, right)
+	return ""
+}
+
+func (c *Checker) assignExpression(node *parser.AssignExpression) string {
+	name, ok := node.Target.(*parser.Identifier)
+	if !ok {
+		c.expression(node.Target)
+		return c.expression(node.Value)
+	}
+
+	sym, _ := c.scope.lookup(name.Value)
+	typ := c.expression(node.Value)
+
+	if node.Operator != "=" {
+		var problem string
+		typ, problem = infixType(strings.TrimSuffix(node.Operator, "="), sym.typ, typ)
+		if problem != "" {
+			c.errorAt(node.Token, "%s", problem)
+			return ""
+		}
+	}
+
+	if sym.annotated && !assignable(sym.typ, typ) {
+		c.errorAt(node.Token, "cannot assign %s to %s of type %s", typ, name.Value, sym.typ)
+	}
+
+	return typ
+}
+
+func (c *Checker) callExpression(node *parser.CallExpression) string {
+	var fn *parser.FunctionLiteral
+	name := ""
+
+	switch callee := node.Function.(type) {
+	case *parser.Identifier:
+		name = callee.Value
+		if sym, ok := c.scope.lookup(callee.Value); ok {
+			fn = sym.fn
+		} else if typ, ok := builtinTypes[callee.Value]; ok {
+			for _, arg := range node.Arguments {
+				c.expression(arg)
+			}
+			return typ
+		}
+	case *parser.FunctionLiteral:
+		c.function(callee)
+		fn = callee
+	default:
+		c.expression(node.Function)
+	}
+
+	for i, arg := range node.Arguments {
+		typ := c.expression(arg)
+		if fn == nil || i >= len(fn.ParameterTypes) {
+			continue
+		}
+
+		want := fn.ParameterTypes[i]
+		if want != "" && !assignable(want, typ) {
+			c.errorAt(node.Token, "argument %d (%s) of %s must be %s, got %s", i+1, fn.Parameters[i].Value, calleeName(name), want, typ)
+		}
+	}
+
+	switch {
+	case fn == nil:
+		return ""
+	case fn.Generator:
+		return interpreter.GENERATOR_OBJ
+	default:
+		return fn.ReturnType
+	}
+}
+
+// Check a NEGOTIATE arm in a scope holding its pattern bindings
+func (c *Checker) matchArm(arm *parser.MatchArm) {
+	c.enclosed(func() {
+		for _, pattern := range arm.Patterns {
+			parser.Walk(pattern, func(node parser.Node) bool {
+				if binding, ok := node.(*parser.BindingPattern); ok {
+					c.scope.names[binding.Name.Value] = symbol{}
+				}
+				if array, ok := node.(*parser.ArrayPattern); ok && array.Rest != nil {
+					c.scope.names[array.Rest.Value] = symbol{typ: interpreter.ARRAY_OBJ}
+				}
+				return true
+			})
+		}
+		c.expression(arm.Guard)
+		c.block(arm.Body)
+		c.expression(arm.Value)
+	})
+}
+
+// Name a called function in a message
+func calleeName(name string) string {
+	if name == "" {
+		return "the function"
+	}
+	return name
+}
@@ -0,0 +1,37 @@
+// file: internal/checker/checker_test.go
+// description: Tests for the static type checker
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/AndrewDonelson/trumplang/internal/lexer"
+	"github.com/AndrewDonelson/trumplang/internal/parser"
+)
+
+func TestBranches(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		errors int
+	}{
+		{"branches disagree", `YUGE c = WINNING; BUILD WALL IF (c) { YUGE x = 1; } ELSE { YUGE x = "s"; } TWEET x - 1;`, 0},
+		{"branches agree", `YUGE c = WINNING; BUILD WALL IF (c) { YUGE x = 1; } ELSE { YUGE x = 2; } TWEET x - "a";`, 1},
+		{"one branch", `YUGE c = WINNING; BUILD WALL IF (c) { YUGE x = 1; } TWEET x - "a";`, 1},
+		{"expression", `YUGE c = WINNING; YUGE z = BUILD WALL IF (c) { YUGE w = 1; w; } ELSE { YUGE w = "q"; w; }; TWEET w - 1;`, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parser.New(lexer.New(tt.input))
+			program := p.Parse()
+			if errs := p.Errors(); len(errs) > 0 {
+				t.Fatalf("parse errors: %v", errs)
+			}
+			if errs := Check(program); len(errs) != tt.errors {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.errors, errs)
+			}
+		})
+	}
+}
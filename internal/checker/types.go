@@ -0,0 +1,167 @@
+// file: internal/checker/types.go
+// description: Type rules for the TRUMP programming language type checker
+
+package checker
+
+import (
+	"fmt"
+
+	"github.com/AndrewDonelson/trumplang/internal/interpreter"
+)
+
+// Result types of built-in functions whose result type is always the same
+var builtinTypes = map[string]string{
+	"len":           interpreter.INTEGER_OBJ,
+	"rest":          interpreter.ARRAY_OBJ,
+	"push":          interpreter.ARRAY_OBJ,
+	"keys":          interpreter.ARRAY_OBJ,
+	"values":        interpreter.ARRAY_OBJ,
+	"array":         interpreter.ARRAY_OBJ,
+	"channel":       interpreter.CHANNEL_OBJ,
+	"select":        interpreter.ARRAY_OBJ,
+	"has":           interpreter.BOOLEAN_OBJ,
+	"upper":         interpreter.STRING_OBJ,
+	"lower":         interpreter.STRING_OBJ,
+	"trim":          interpreter.STRING_OBJ,
+	"split":         interpreter.ARRAY_OBJ,
+	"join":          interpreter.STRING_OBJ,
+	"replace":       interpreter.STRING_OBJ,
+	"contains":      interpreter.BOOLEAN_OBJ,
+	"starts_with":   interpreter.BOOLEAN_OBJ,
+	"ends_with":     interpreter.BOOLEAN_OBJ,
+	"index_of":      interpreter.INTEGER_OBJ,
+	"repeat":        interpreter.STRING_OBJ,
+	"chars":         interpreter.ARRAY_OBJ,
+	"decimal":       interpreter.DECIMAL_OBJ,
+	"round":         interpreter.DECIMAL_OBJ,
+	"divide":        interpreter.DECIMAL_OBJ,
+	"rounding_mode": interpreter.STRING_OBJ,
+}
+
+// Check whether a value of type have can be stored where want is expected.
+// Integers of either size are interchangeable, since BIGLY arithmetic moves
+// between them as values grow and shrink.
+func assignable(want, have string) bool {
+	return have == "" || want == have || (isInteger(want) && isInteger(have))
+}
+
+func isInteger(typ string) bool {
+	return typ == interpreter.INTEGER_OBJ || typ == interpreter.BIG_INT_OBJ
+}
+
+func isNumeric(typ string) bool {
+	return isInteger(typ) || typ == interpreter.DECIMAL_OBJ || typ == interpreter.FLOAT_OBJ
+}
+
+func isComparison(operator string) bool {
+	switch operator {
+	case "<", ">", "<=", ">=", "==", "!=":
+		return true
+	default:
+		return false
+	}
+}
+
+// Work out the result type of an infix expression the way the interpreter
+// evaluates it, describing the problem if it would fail with any values of
+// these types. Unknown operand types are never a problem.
+func infixType(operator, left, right string) (string, string) {
+	if operator == "&&" || operator == "||" {
+		return interpreter.BOOLEAN_OBJ, ""
+	}
+	if operator == "IN" {
+		return membershipType(left, right)
+	}
+
+	if left == "" || right == "" {
+		if isComparison(operator) {
+			return interpreter.BOOLEAN_OBJ, ""
+		}
+		return "", ""
+	}
+
+	switch {
+	case isNumeric(left) && isNumeric(right):
+		return numericInfixType(operator, left, right)
+	case left == interpreter.STRING_OBJ && right == interpreter.STRING_OBJ:
+		switch operator {
+		case "+":
+			return interpreter.STRING_OBJ, ""
+		case "==", "!=":
+			return interpreter.BOOLEAN_OBJ, ""
+		}
+	case left == interpreter.STRING_OBJ && concatenates(right), concatenates(left) && right == interpreter.STRING_OBJ:
+		// Strings only combine with other values through concatenation
+		if operator == "+" {
+			return interpreter.STRING_OBJ, ""
+		}
+	case operator == "==" || operator == "!=":
+		return interpreter.BOOLEAN_OBJ, ""
+	case left != right:
+		return "", fmt.Sprintf("type mismatch: %s %s %s", left, operator, right)
+	}
+
+	return "", fmt.Sprintf("unknown operator: %s %s %s", left, operator, right)
+}
+
+// Work out the result of a membership check, which can only fail for
+// containers other than arrays, strings, hashes and ranges, or when looking
+// for something other than a string in a string
+func membershipType(item, container string) (string, string) {
+	switch container {
+	case "", interpreter.ARRAY_OBJ, interpreter.HASH_OBJ, interpreter.RANGE_OBJ:
+		return interpreter.BOOLEAN_OBJ, ""
+	case interpreter.STRING_OBJ:
+		if item == "" || item == interpreter.STRING_OBJ {
+			return interpreter.BOOLEAN_OBJ, ""
+		}
+		return "", fmt.Sprintf("type mismatch: %s IN %s", item, container)
+	default:
+		return "", fmt.Sprintf("cannot look for a value IN %s", container)
+	}
+}
+
+// Check whether a value of a type can be concatenated onto a string
+func concatenates(typ string) bool {
+	switch typ {
+	case interpreter.BOOLEAN_OBJ, interpreter.ARRAY_OBJ, interpreter.HASH_OBJ:
+		return true
+	default:
+		return isNumeric(typ)
+	}
+}
+
+// Work out the result type of an infix expression between numbers
+func numericInfixType(operator, left, right string) (string, string) {
+	if isComparison(operator) {
+		return interpreter.BOOLEAN_OBJ, ""
+	}
+
+	// The widest operand decides how the operation is done
+	widest := interpreter.INTEGER_OBJ
+	switch {
+	case left == interpreter.DECIMAL_OBJ || right == interpreter.DECIMAL_OBJ:
+		widest = interpreter.DECIMAL_OBJ
+	case left == interpreter.FLOAT_OBJ || right == interpreter.FLOAT_OBJ:
+		widest = interpreter.FLOAT_OBJ
+	}
+
+	switch operator {
+	case "+", "-", "*", "/", "%":
+		return widest, ""
+	case "**":
+		switch widest {
+		case interpreter.FLOAT_OBJ:
+			return interpreter.FLOAT_OBJ, ""
+		case interpreter.INTEGER_OBJ:
+			// Negative exponents give a FLOAT
+			return "", ""
+		}
+	case "&", "|", "^", "<<", ">>":
+		if widest == interpreter.INTEGER_OBJ {
+			return interpreter.INTEGER_OBJ, ""
+		}
+	}
+
+	return "", fmt.Sprintf("unknown operator: %s %s %s", left, operator, right)
+}
@@ -42,7 +42,7 @@ func BuildTrump(args []string, verbose bool, noFakeNews bool) {
 	}
 
 	// Parse the program
-	l := lexer.New(string(input))
+	l := lexer.NewWithFile(string(input), inputFile)
 	p := parser.New(l)
 	program := p.Parse()
 
@@ -55,6 +55,8 @@ func BuildTrump(args []string, verbose bool, noFakeNews bool) {
 		os.Exit(1)
 	}
 
+	printWarnings(p.Warnings(), noFakeNews)
+
 	// Get output file name
 	outputFile := strings.TrimSuffix(inputFile, ".trump") + ".djt"
 
@@ -0,0 +1,67 @@
+// file: internal/cmd/check.go
+// description: Check command for the TRUMP language
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AndrewDonelson/trumplang/internal/checker"
+	"github.com/AndrewDonelson/trumplang/internal/errors"
+	"github.com/AndrewDonelson/trumplang/internal/lexer"
+	"github.com/AndrewDonelson/trumplang/internal/parser"
+)
+
+// CheckTrump type checks a Trump program without running it, reporting
+// mismatches the interpreter would otherwise only find at runtime
+func CheckTrump(args []string, noFakeNews bool) {
+	if len(args) < 1 {
+		fmt.Println(errors.NewTrumpError(errors.MISSING_ARGUMENT, "Please specify a .trump file to check", 0, 0))
+		os.Exit(1)
+	}
+
+	inputFile := args[0]
+
+	// Check file extension
+	if !strings.HasSuffix(inputFile, ".trump") {
+		fmt.Println(errors.NewTrumpError(errors.INVALID_FILE_TYPE, "Expected a .trump file", 0, 0))
+		os.Exit(1)
+	}
+
+	// Read input file
+	input, err := os.ReadFile(inputFile)
+	if err != nil {
+		fmt.Println(errors.NewTrumpError(errors.FILE_NOT_FOUND, "Cannot read file: "+inputFile, 0, 0))
+		os.Exit(1)
+	}
+
+	// Parse the program
+	l := lexer.NewWithFile(string(input), inputFile)
+	p := parser.New(l)
+	program := p.Parse()
+
+	// Check for parsing errors
+	if len(p.Errors()) > 0 {
+		fmt.Println(errors.NewTrumpError(errors.SYNTAX_ERROR, "Parsing errors", 0, 0))
+		for _, err := range p.Errors() {
+			fmt.Println("   ", err)
+		}
+		os.Exit(1)
+	}
+
+	printWarnings(p.Warnings(), noFakeNews)
+
+	// Check the types
+	typeErrors := checker.Check(program)
+	if len(typeErrors) > 0 {
+		fmt.Printf("TYPE CHECK FAILED! %d SAD type errors found:\n", len(typeErrors))
+		for _, err := range typeErrors {
+			fmt.Println("   ", err)
+		}
+		os.Exit(1)
+	}
+
+	fmt.Println("TYPE CHECK PASSED! The types are PERFECT, everybody says so!")
+}
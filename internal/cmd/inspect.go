@@ -44,7 +44,7 @@ func InspectTrump(args []string) {
 	}
 
 	// Create a lexer for the input
-	l := lexer.New(string(input))
+	l := lexer.NewWithFile(string(input), inputFile)
 
 	// Check for lexer errors
 	fmt.Println("INSPECTING FILE:", inputFile)
@@ -72,10 +72,12 @@ func InspectTrump(args []string) {
 		switch {
 		case tok.Type == "IDENT":
 			identifierCount++
-		case tok.Type == "INT" || tok.Type == "FLOAT" || tok.Type == "STRING":
+		case tok.Type == "INT" || tok.Type == "FLOAT" || tok.Type == "STRING" || tok.Type == "STRING_START":
 			literalCount++
 		case tok.Type == "COMMENT":
 			commentCount++
+		case tok.Type == "STRING_MID" || tok.Type == "STRING_END":
+			// Continuations of an interpolated string already counted
 		case tok.Type != "EOF" && tok.Type != "ILLEGAL" &&
 			tok.Type != ";" && tok.Type != "," && tok.Type != "(" && tok.Type != ")" &&
 			tok.Type != "{" && tok.Type != "}" && tok.Type != "[" && tok.Type != "]" &&
@@ -110,7 +112,7 @@ func InspectTrump(args []string) {
 	fmt.Printf("  Comments: %d\n", commentCount)
 
 	// Reset the lexer and parse the program
-	l = lexer.New(string(input))
+	l = lexer.NewWithFile(string(input), inputFile)
 	p := parser.New(l)
 	program := p.Parse()
 
@@ -165,6 +167,7 @@ func InspectTrump(args []string) {
 
 	// Print syntax stats
 	fmt.Println("No syntax errors found!")
+	printWarnings(p.Warnings(), false)
 	fmt.Println("\nStatement statistics:")
 	fmt.Printf("  Total statements: %d\n", statementCount)
 	fmt.Printf("  Variable declarations: %d\n", letCount)
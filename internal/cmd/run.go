@@ -14,8 +14,9 @@ import (
 	"github.com/AndrewDonelson/trumplang/internal/parser"
 )
 
-// RunTrump runs a Trump program
-func RunTrump(args []string, verbose bool) {
+// RunTrump runs a Trump program. In strict mode out-of-range index reads are
+// runtime errors.
+func RunTrump(args []string, verbose, strict, noFakeNews bool) {
 	if len(args) < 1 {
 		fmt.Println(errors.NewTrumpError(errors.MISSING_ARGUMENT, "Please specify a .trump file to run", 0, 0))
 		os.Exit(1)
@@ -53,7 +54,7 @@ func RunTrump(args []string, verbose bool) {
 	}
 
 	// Parse the program
-	l := lexer.New(string(input))
+	l := lexer.NewWithFile(string(input), inputFile)
 	p := parser.New(l)
 	program := p.Parse()
 
@@ -66,8 +67,12 @@ func RunTrump(args []string, verbose bool) {
 		os.Exit(1)
 	}
 
+	printWarnings(p.Warnings(), noFakeNews)
+
 	// Create an evaluator and run the program
 	evaluator := interpreter.NewEvaluator()
+	evaluator.SetFile(inputFile)
+	evaluator.SetStrict(strict)
 	result := evaluator.Eval(program)
 
 	// Check for evaluation errors
@@ -15,8 +15,10 @@ func PrintUsage() {
 	fmt.Println("  run <file.trump>      - Run a .trump file directly")
 	fmt.Println("  create <project>      - Create a new Trump project")
 	fmt.Println("  inspect <file.trump>  - Check a program for errors without compiling")
+	fmt.Println("  check <file.trump>    - Type check a program without running it")
 	fmt.Println()
 	fmt.Println("Flags:")
 	fmt.Println("  --verbose             - Enable verbose output")
-	fmt.Println("  --no-fake-news        - Suppress warnings")
+	fmt.Println("  --no-fake-news        - Suppress warnings (build, run, check)")
+	fmt.Println("  --strict              - Make out-of-range index reads errors (run)")
 }
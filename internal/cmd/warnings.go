@@ -0,0 +1,20 @@
+// file: internal/cmd/warnings.go
+// description: Warning output for TRUMP commands
+
+package cmd
+
+import (
+	"fmt"
+)
+
+// printWarnings displays parser warnings unless they have been suppressed
+func printWarnings(warnings []string, noFakeNews bool) {
+	if noFakeNews || len(warnings) == 0 {
+		return
+	}
+
+	fmt.Println("FAKE NEWS ALERT! Your code works, but some of it looks WEAK:")
+	for _, warning := range warnings {
+		fmt.Println("   ", warning)
+	}
+}
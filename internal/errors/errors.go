@@ -20,6 +20,8 @@ const (
 	ILLEGAL_CHARACTER    = "ILLEGAL_CHARACTER"
 	UNTERMINATED_STRING  = "UNTERMINATED_STRING"
 	UNTERMINATED_COMMENT = "UNTERMINATED_COMMENT"
+	INVALID_ESCAPE       = "INVALID_ESCAPE"
+	INVALID_NUMBER       = "INVALID_NUMBER"
 
 	// Parser errors
 	UNEXPECTED_TOKEN    = "UNEXPECTED_TOKEN"
@@ -41,6 +43,16 @@ const (
 	OUT_OF_MEMORY  = "OUT_OF_MEMORY"
 	RUNTIME_ERROR  = "RUNTIME_ERROR"
 
+	TYPE_MISMATCH        = "TYPE_MISMATCH"
+	UNDEFINED_IDENTIFIER = "UNDEFINED_IDENTIFIER"
+	FAKE_NEWS            = "FAKE_NEWS" // Raised by a FAKE_NEWS statement
+	IMPORT_ERROR         = "IMPORT_ERROR"
+	INDEX_OUT_OF_RANGE   = "INDEX_OUT_OF_RANGE"
+	CONSTANT_ASSIGNMENT  = "CONSTANT_ASSIGNMENT"
+	REDECLARATION        = "REDECLARATION"
+	NUMBER_FORMAT        = "NUMBER_FORMAT" // A string that does not hold a number
+	DEADLOCK             = "DEADLOCK"      // Every task is waiting on another
+
 	// Mathematical errors
 	DIVISION_BY_ZERO     = "DIVISION_BY_ZERO"
 	FLOATING_POINT_ERROR = "FLOATING_POINT_ERROR"
@@ -65,6 +77,14 @@ func (e *TrumpError) Error() string {
 	return e.String()
 }
 
+// InFile prefixes a diagnostic with the name of the file it came from
+func InFile(file, msg string) string {
+	if file == "" {
+		return msg
+	}
+	return file + ": " + msg
+}
+
 // Map of error types to Trump-themed error messages
 var errorMessages = map[string][]string{
 	// Lexer errors
@@ -0,0 +1,50 @@
+// file: internal/interpreter/annotations.go
+// description: Runtime checks of type annotations for the TRUMP programming language
+
+package interpreter
+
+import "github.com/AndrewDonelson/trumplang/internal/errors"
+
+// Check whether a value can be stored where a type annotation expects one,
+// the way trumpc check does. Integers of either size are interchangeable,
+// built-in functions are FUNCTIONs and COVFEFE fits any type. An empty
+// annotation accepts anything.
+func matchesAnnotation(annotation string, val Object) bool {
+	switch val.Type() {
+	case annotation, NULL_OBJ:
+		return true
+	case INTEGER_OBJ, BIG_INT_OBJ:
+		return annotation == "" || annotation == INTEGER_OBJ || annotation == BIG_INT_OBJ
+	case BUILTIN_OBJ:
+		return annotation == "" || annotation == FUNCTION_OBJ
+	default:
+		return annotation == ""
+	}
+}
+
+// Check the value given to an annotated variable
+func checkVariableAnnotation(name, annotation string, val Object) *Error {
+	if matchesAnnotation(annotation, val) {
+		return nil
+	}
+	return newCodedError(errors.TYPE_MISMATCH, "cannot assign %s to %s of type %s", val.Type(), name, annotation)
+}
+
+// Check the arguments of a call against the function's parameter annotations
+func checkArgumentAnnotations(fn *Function, args []Object) *Error {
+	for i, arg := range args {
+		if i >= len(fn.ParameterTypes) || i >= len(fn.Parameters) {
+			break
+		}
+
+		want := fn.ParameterTypes[i]
+		if !matchesAnnotation(want, arg) {
+			name := fn.Name
+			if name == "" {
+				name = "the function"
+			}
+			return newCodedError(errors.TYPE_MISMATCH, "argument %d (%s) of %s must be %s, got %s", i+1, fn.Parameters[i].Value, name, want, arg.Type())
+		}
+	}
+	return nil
+}
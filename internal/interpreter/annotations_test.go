@@ -0,0 +1,39 @@
+// file: internal/interpreter/annotations_test.go
+// description: Tests for runtime checks of type annotations
+
+package interpreter
+
+import "testing"
+
+// Run a program and return the message of the error it raised, or "none"
+func mismatch(program string) string {
+	return `YUGE msg = "none";
+	DENY { ` + program + ` } BLAME (err) { msg = err["message"]; }
+	msg;`
+}
+
+func TestAnnotations(t *testing.T) {
+	runEvalTests(t, []evalTest{
+		{"variable", mismatch(`YUGE x: INTEGER = "s";`), "cannot assign STRING to x of type INTEGER"},
+		{"assignment", mismatch(`YUGE n: INTEGER = 1; n += 2; n = "x";`), "cannot assign STRING to n of type INTEGER"},
+		{"integers of either size", mismatch(`YUGE n: BIG_INTEGER = 1;`), "none"},
+		{"built-in function", mismatch(`YUGE f: FUNCTION = len;`), "none"},
+		{
+			"parameter",
+			mismatch(`YUGE FUNCTION add(a: INTEGER, b: INTEGER) { RETURN a + b; } add(1, "2");`),
+			"argument 2 (b) of add must be INTEGER, got STRING",
+		},
+		{
+			"reassigned parameter",
+			mismatch(`YUGE FUNCTION f(a: INTEGER) { a = "s"; RETURN a; } f(1);`),
+			"cannot assign STRING to a of type INTEGER",
+		},
+		{
+			"return",
+			mismatch(`YUGE FUNCTION name(): STRING { RETURN 45; } name();`),
+			"cannot return INTEGER from a function returning STRING",
+		},
+		{"lambda", mismatch(`YUGE g = (x: STRING) => x; g(3);`), "argument 1 (x) of the function must be STRING, got INTEGER"},
+		{"annotated result", `YUGE FUNCTION add(a: INTEGER, b: INTEGER): INTEGER { RETURN a + b; } add(1, 2);`, "3"},
+	})
+}
@@ -0,0 +1,231 @@
+// file: internal/interpreter/bigly.go
+// description: Overflow-checked and arbitrary-precision integer arithmetic for the TRUMP language
+
+package interpreter
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/AndrewDonelson/trumplang/internal/errors"
+)
+
+// Handle an integer operation whose result does not fit in 64 bits. BIGLY
+// files redo it with arbitrary precision, everything else gets an error.
+func (e *Evaluator) integerOverflow(operator string, left, right int64) Object {
+	if e.bigly {
+		return e.evalBigIntegerInfixExpression(operator, big.NewInt(left), big.NewInt(right))
+	}
+
+	return newCodedError(errors.INTEGER_OVERFLOW, "integer overflow: %d %s %d (start the file with BIGLY; for arbitrary precision)", left, operator, right)
+}
+
+// Evaluate an infix expression between numbers where at least one operand
+// is a BigInteger. Floats win over integers, as with Integer and Float.
+func (e *Evaluator) evalMixedBigIntegerInfixExpression(operator string, left, right Object) Object {
+	if left.Type() == FLOAT_OBJ || right.Type() == FLOAT_OBJ {
+		return e.evalFloatInfixExpression(operator, &Float{Value: toFloat(left)}, &Float{Value: toFloat(right)})
+	}
+
+	return e.evalBigIntegerInfixExpression(operator, toBigInt(left), toBigInt(right))
+}
+
+// Evaluate an infix expression between arbitrary-precision integers
+func (e *Evaluator) evalBigIntegerInfixExpression(operator string, leftVal, rightVal *big.Int) Object {
+	result := new(big.Int)
+
+	switch operator {
+	case "+":
+		return bigResult(result.Add(leftVal, rightVal))
+	case "-":
+		return bigResult(result.Sub(leftVal, rightVal))
+	case "*":
+		return bigResult(result.Mul(leftVal, rightVal))
+	case "/":
+		if rightVal.Sign() == 0 {
+			return newCodedError(errors.DIVISION_BY_ZERO, "division by zero")
+		}
+		return bigResult(result.Quo(leftVal, rightVal))
+	case "%":
+		if rightVal.Sign() == 0 {
+			return newCodedError(errors.DIVISION_BY_ZERO, "division by zero")
+		}
+		return bigResult(result.Rem(leftVal, rightVal))
+	case "**":
+		if rightVal.Sign() < 0 {
+			leftFloat, _ := new(big.Float).SetInt(leftVal).Float64()
+			rightFloat, _ := new(big.Float).SetInt(rightVal).Float64()
+			return &Float{Value: math.Pow(leftFloat, rightFloat)}
+		}
+		if !rightVal.IsInt64() || rightVal.Int64() > math.MaxInt32 {
+			return newCodedError(errors.INTEGER_OVERFLOW, "exponent too large: %s", rightVal)
+		}
+		return bigResult(result.Exp(leftVal, rightVal, nil))
+	case "&":
+		return bigResult(result.And(leftVal, rightVal))
+	case "|":
+		return bigResult(result.Or(leftVal, rightVal))
+	case "^":
+		return bigResult(result.Xor(leftVal, rightVal))
+	case "<<", ">>":
+		if rightVal.Sign() < 0 {
+			return newError("negative shift count: %s", rightVal)
+		}
+		if !rightVal.IsInt64() || rightVal.Int64() > math.MaxInt32 {
+			return newCodedError(errors.INTEGER_OVERFLOW, "shift count too large: %s", rightVal)
+		}
+		if operator == "<<" {
+			return bigResult(result.Lsh(leftVal, uint(rightVal.Int64())))
+		}
+		return bigResult(result.Rsh(leftVal, uint(rightVal.Int64())))
+	case "<":
+		return e.nativeBoolToBooleanObject(leftVal.Cmp(rightVal) < 0)
+	case ">":
+		return e.nativeBoolToBooleanObject(leftVal.Cmp(rightVal) > 0)
+	case "<=":
+		return e.nativeBoolToBooleanObject(leftVal.Cmp(rightVal) <= 0)
+	case ">=":
+		return e.nativeBoolToBooleanObject(leftVal.Cmp(rightVal) >= 0)
+	case "==":
+		return e.nativeBoolToBooleanObject(leftVal.Cmp(rightVal) == 0)
+	case "!=":
+		return e.nativeBoolToBooleanObject(leftVal.Cmp(rightVal) != 0)
+	default:
+		return newError("unknown operator: %s %s %s", BIG_INT_OBJ, operator, BIG_INT_OBJ)
+	}
+}
+
+// Wrap an arbitrary-precision result, narrowing it back to an Integer when
+// it fits in 64 bits
+func bigResult(value *big.Int) Object {
+	if value.IsInt64() {
+		return &Integer{Value: value.Int64()}
+	}
+	return &BigInteger{Value: value}
+}
+
+// Check whether an object is an Integer, BigInteger, Decimal or Float
+func isNumber(obj Object) bool {
+	switch obj.(type) {
+	case *Integer, *BigInteger, *Decimal, *Float:
+		return true
+	default:
+		return false
+	}
+}
+
+// Convert an Integer or BigInteger to an arbitrary-precision integer
+func toBigInt(obj Object) *big.Int {
+	switch obj := obj.(type) {
+	case *Integer:
+		return big.NewInt(obj.Value)
+	case *BigInteger:
+		return obj.Value
+	default:
+		return new(big.Int)
+	}
+}
+
+// Convert any number to a float, rounding if needed
+func toFloat(obj Object) float64 {
+	switch obj := obj.(type) {
+	case *Integer:
+		return float64(obj.Value)
+	case *BigInteger:
+		value, _ := new(big.Float).SetInt(obj.Value).Float64()
+		return value
+	case *Decimal:
+		value, _ := new(big.Rat).SetFrac(obj.Value, pow10(obj.Scale)).Float64()
+		return value
+	case *Float:
+		return obj.Value
+	default:
+		return 0
+	}
+}
+
+// Compare two numbers of any type, reporting false if either is not a number
+func compareNumbers(left, right Object) (int, bool) {
+	if !isNumber(left) || !isNumber(right) {
+		return 0, false
+	}
+
+	if left.Type() == DECIMAL_OBJ || right.Type() == DECIMAL_OBJ {
+		leftVal, leftOk := toDecimal(left)
+		rightVal, rightOk := toDecimal(right)
+		if !leftOk || !rightOk {
+			return 0, false
+		}
+		l, r, _ := alignDecimals(leftVal, rightVal)
+		return l.Cmp(r), true
+	}
+
+	if left.Type() == FLOAT_OBJ || right.Type() == FLOAT_OBJ {
+		leftVal, rightVal := toFloat(left), toFloat(right)
+		switch {
+		case leftVal < rightVal:
+			return -1, true
+		case leftVal > rightVal:
+			return 1, true
+		default:
+			return 0, true
+		}
+	}
+
+	return toBigInt(left).Cmp(toBigInt(right)), true
+}
+
+// Add two integers, reporting whether the result overflowed
+func addInt64(a, b int64) (int64, bool) {
+	result := a + b
+	return result, (b > 0 && result < a) || (b < 0 && result > a)
+}
+
+// Subtract two integers, reporting whether the result overflowed
+func subInt64(a, b int64) (int64, bool) {
+	result := a - b
+	return result, (b > 0 && result > a) || (b < 0 && result < a)
+}
+
+// Multiply two integers, reporting whether the result overflowed
+func mulInt64(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, false
+	}
+	result := a * b
+	overflow := result/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64)
+	return result, overflow
+}
+
+// Shift an integer left, reporting whether any bits were lost
+func shlInt64(a, n int64) (int64, bool) {
+	if a == 0 {
+		return 0, false
+	}
+	if n >= 64 {
+		return 0, true
+	}
+	result := a << n
+	return result, result>>n != a
+}
+
+// Raise an integer to a non-negative integer power by repeated squaring,
+// reporting whether the result overflowed
+func powInt64(base, exp int64) (int64, bool) {
+	result := int64(1)
+	for exp > 0 {
+		var overflow bool
+		if exp&1 == 1 {
+			if result, overflow = mulInt64(result, base); overflow {
+				return 0, true
+			}
+		}
+		exp >>= 1
+		if exp > 0 {
+			if base, overflow = mulInt64(base, base); overflow {
+				return 0, true
+			}
+		}
+	}
+	return result, false
+}
@@ -0,0 +1,295 @@
+// file: internal/interpreter/billions.go
+// description: Exact fixed-point decimal arithmetic (BILLIONS) for the TRUMP language
+
+package interpreter
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/AndrewDonelson/trumplang/internal/errors"
+)
+
+// Rounding modes for decimal division and rounding
+const (
+	ROUND_HALF_EVEN = "HALF_EVEN" // Ties go to the even neighbour (banker's rounding)
+	ROUND_HALF_UP   = "HALF_UP"   // Ties go away from zero
+	ROUND_HALF_DOWN = "HALF_DOWN" // Ties go towards zero
+	ROUND_UP        = "UP"        // Away from zero
+	ROUND_DOWN      = "DOWN"      // Towards zero (truncate)
+	ROUND_CEILING   = "CEILING"   // Towards positive infinity
+	ROUND_FLOOR     = "FLOOR"     // Towards negative infinity
+)
+
+// Check whether a rounding mode name is known
+func isRoundingMode(mode string) bool {
+	switch mode {
+	case ROUND_HALF_EVEN, ROUND_HALF_UP, ROUND_HALF_DOWN, ROUND_UP, ROUND_DOWN, ROUND_CEILING, ROUND_FLOOR:
+		return true
+	default:
+		return false
+	}
+}
+
+// The largest exponent a decimal can be written with. Larger ones would take
+// a very long time to expand into digits.
+const maxDecimalExponent = 10000
+
+// Parse a decimal from its source form, e.g. "1_250.75" or "4.5e9"
+func parseDecimal(s string) (*Decimal, bool) {
+	s = strings.ReplaceAll(s, "_", "")
+
+	// Split off the exponent
+	exp := 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		n, err := strconv.Atoi(s[i+1:])
+		if err != nil || n > maxDecimalExponent || n < -maxDecimalExponent {
+			return nil, false
+		}
+		exp = n
+		s = s[:i]
+	}
+
+	// Split the mantissa at the decimal point
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+
+	digits := intPart + fracPart
+	if digits == "" || digits == "-" || digits == "+" {
+		return nil, false
+	}
+
+	value, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, false
+	}
+
+	scale := len(fracPart) - exp
+	if scale < 0 {
+		value.Mul(value, pow10(-scale))
+		scale = 0
+	}
+
+	return &Decimal{Value: value, Scale: scale}, true
+}
+
+// Parse a decimal from a string. The integer part may be grouped with
+// commas the way decimals print, e.g. "1,234,567.50", but only in groups of
+// three digits after a first group of one to three. Underscores belong to
+// source literals and are not accepted.
+func parseDecimalString(s string) (*Decimal, bool) {
+	if strings.Contains(s, "_") {
+		return nil, false
+	}
+
+	intPart := s
+	if i := strings.IndexAny(s, ".eE"); i >= 0 {
+		if strings.Contains(s[i:], ",") {
+			return nil, false
+		}
+		intPart = s[:i]
+	}
+
+	if strings.Contains(intPart, ",") {
+		groups := strings.Split(strings.TrimLeft(intPart, "+-"), ",")
+		if len(groups[0]) < 1 || len(groups[0]) > 3 {
+			return nil, false
+		}
+		for _, group := range groups[1:] {
+			if len(group) != 3 {
+				return nil, false
+			}
+		}
+		s = strings.ReplaceAll(s, ",", "")
+	}
+
+	return parseDecimal(s)
+}
+
+// Convert a number or numeric string to a decimal. Floats convert through
+// their shortest exact representation, so 0.1 becomes 0.1.
+func toDecimal(obj Object) (*Decimal, bool) {
+	switch obj := obj.(type) {
+	case *Decimal:
+		return obj, true
+	case *Integer:
+		return &Decimal{Value: big.NewInt(obj.Value)}, true
+	case *BigInteger:
+		return &Decimal{Value: obj.Value}, true
+	case *Float:
+		if math.IsNaN(obj.Value) || math.IsInf(obj.Value, 0) {
+			return nil, false
+		}
+		return parseDecimal(strconv.FormatFloat(obj.Value, 'f', -1, 64))
+	case *String:
+		return parseDecimalString(strings.TrimSpace(obj.Value))
+	default:
+		return nil, false
+	}
+}
+
+// Evaluate an infix expression between numbers where at least one operand
+// is a decimal. Addition, subtraction and multiplication are exact; division
+// keeps the larger scale of its operands and rounds with the current mode.
+func (e *Evaluator) evalDecimalInfixExpression(operator string, left, right Object) Object {
+	leftVal, ok := toDecimal(left)
+	if !ok {
+		return newError("cannot convert %s to BILLIONS", left.Inspect())
+	}
+	rightVal, ok := toDecimal(right)
+	if !ok {
+		return newError("cannot convert %s to BILLIONS", right.Inspect())
+	}
+
+	switch operator {
+	case "+":
+		l, r, scale := alignDecimals(leftVal, rightVal)
+		return &Decimal{Value: new(big.Int).Add(l, r), Scale: scale}
+	case "-":
+		l, r, scale := alignDecimals(leftVal, rightVal)
+		return &Decimal{Value: new(big.Int).Sub(l, r), Scale: scale}
+	case "*":
+		return &Decimal{Value: new(big.Int).Mul(leftVal.Value, rightVal.Value), Scale: leftVal.Scale + rightVal.Scale}
+	case "/":
+		scale := leftVal.Scale
+		if rightVal.Scale > scale {
+			scale = rightVal.Scale
+		}
+		return divideDecimals(leftVal, rightVal, scale, e.rounding)
+	case "%":
+		l, r, scale := alignDecimals(leftVal, rightVal)
+		if r.Sign() == 0 {
+			return newCodedError(errors.DIVISION_BY_ZERO, "division by zero")
+		}
+		return &Decimal{Value: new(big.Int).Rem(l, r), Scale: scale}
+	case "<", ">", "<=", ">=", "==", "!=":
+		l, r, _ := alignDecimals(leftVal, rightVal)
+		return e.compareResult(operator, l.Cmp(r))
+	default:
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+// Turn the result of a three-way comparison into the boolean for operator
+func (e *Evaluator) compareResult(operator string, cmp int) Object {
+	switch operator {
+	case "<":
+		return e.nativeBoolToBooleanObject(cmp < 0)
+	case ">":
+		return e.nativeBoolToBooleanObject(cmp > 0)
+	case "<=":
+		return e.nativeBoolToBooleanObject(cmp <= 0)
+	case ">=":
+		return e.nativeBoolToBooleanObject(cmp >= 0)
+	case "==":
+		return e.nativeBoolToBooleanObject(cmp == 0)
+	default:
+		return e.nativeBoolToBooleanObject(cmp != 0)
+	}
+}
+
+// Divide two decimals, rounding the quotient to the given number of places
+func divideDecimals(left, right *Decimal, places int, mode string) Object {
+	if right.Value.Sign() == 0 {
+		return newCodedError(errors.DIVISION_BY_ZERO, "division by zero")
+	}
+
+	// left/right * 10^places, as a ratio of integers
+	num := new(big.Int).Mul(left.Value, pow10(places+right.Scale))
+	den := new(big.Int).Mul(right.Value, pow10(left.Scale))
+
+	return &Decimal{Value: roundQuotient(num, den, mode), Scale: places}
+}
+
+// Round a decimal to the given number of places
+func roundDecimal(d *Decimal, places int, mode string) *Decimal {
+	if places >= d.Scale {
+		return &Decimal{Value: new(big.Int).Mul(d.Value, pow10(places-d.Scale)), Scale: places}
+	}
+
+	return &Decimal{Value: roundQuotient(d.Value, pow10(d.Scale-places), mode), Scale: places}
+}
+
+// Divide num by den, rounding the result to an integer with the given mode
+func roundQuotient(num, den *big.Int, mode string) *big.Int {
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() == 0 {
+		return quo
+	}
+
+	// Direction away from zero, and how the remainder compares to a half
+	sign := int64(num.Sign() * den.Sign())
+	half := new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).CmpAbs(den)
+
+	var away bool
+	switch mode {
+	case ROUND_UP:
+		away = true
+	case ROUND_DOWN:
+		away = false
+	case ROUND_CEILING:
+		away = sign > 0
+	case ROUND_FLOOR:
+		away = sign < 0
+	case ROUND_HALF_UP:
+		away = half >= 0
+	case ROUND_HALF_DOWN:
+		away = half > 0
+	default:
+		away = half > 0 || (half == 0 && quo.Bit(0) == 1)
+	}
+
+	if away {
+		quo.Add(quo, big.NewInt(sign))
+	}
+	return quo
+}
+
+// Bring two decimals to the same scale, returning their unscaled values
+func alignDecimals(left, right *Decimal) (*big.Int, *big.Int, int) {
+	switch {
+	case left.Scale < right.Scale:
+		return new(big.Int).Mul(left.Value, pow10(right.Scale-left.Scale)), right.Value, right.Scale
+	case left.Scale > right.Scale:
+		return left.Value, new(big.Int).Mul(right.Value, pow10(left.Scale-right.Scale)), left.Scale
+	default:
+		return left.Value, right.Value, left.Scale
+	}
+}
+
+// Compute 10^n
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// Format a decimal with thousands separators, e.g. -1,250,000.50
+func formatDecimal(d *Decimal) string {
+	digits := new(big.Int).Abs(d.Value).String()
+	if len(digits) <= d.Scale {
+		digits = strings.Repeat("0", d.Scale-len(digits)+1) + digits
+	}
+
+	intPart := digits[:len(digits)-d.Scale]
+	fracPart := digits[len(digits)-d.Scale:]
+
+	var out strings.Builder
+	if d.Value.Sign() < 0 {
+		out.WriteByte('-')
+	}
+	for i, ch := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			out.WriteByte(',')
+		}
+		out.WriteRune(ch)
+	}
+	if d.Scale > 0 {
+		out.WriteByte('.')
+		out.WriteString(fracPart)
+	}
+
+	return out.String()
+}
@@ -0,0 +1,45 @@
+// file: internal/interpreter/billions_test.go
+// description: Tests for exact BILLIONS decimals
+
+package interpreter
+
+import "testing"
+
+// Defines formatError(s), which returns the code of the error decimal(s)
+// raises, or "none"
+const formatError = `
+YUGE FUNCTION formatError(s) {
+	YUGE code = "none";
+	DENY { decimal(s); } BLAME (err) { code = err["code"]; }
+	RETURN code;
+}
+`
+
+func TestDecimalStrings(t *testing.T) {
+	runEvalTests(t, []evalTest{
+		{"plain", `decimal("12.50");`, "12.50"},
+		{"commas", `decimal("-1,234,567.50");`, "-1,234,567.50"},
+		{"short group", formatError + `formatError("1,2");`, "NUMBER_FORMAT"},
+		{"long first group", formatError + `formatError("1234,567");`, "NUMBER_FORMAT"},
+		{"leading comma", formatError + `formatError(",123");`, "NUMBER_FORMAT"},
+		{"comma in the fraction", formatError + `formatError("1.2,3");`, "NUMBER_FORMAT"},
+		{"comma in the exponent", formatError + `formatError("1e1,0");`, "NUMBER_FORMAT"},
+		{"underscore", formatError + `formatError("1_000");`, "NUMBER_FORMAT"},
+		{"printed form", `decimal("" + decimal("1,000.25") * 2);`, "2,000.50"},
+		{"largest exponent", `decimal("1e10000") > 0;`, "WINNING"},
+		{
+			"exponent too large",
+			`YUGE code = "";
+			DENY { decimal("1e999999999"); } BLAME (err) { code = err["code"]; }
+			code;`,
+			"NUMBER_FORMAT",
+		},
+		{
+			"exponent too small",
+			`YUGE code = "";
+			DENY { decimal("1e-10001"); } BLAME (err) { code = err["code"]; }
+			code;`,
+			"NUMBER_FORMAT",
+		},
+	})
+}
@@ -4,7 +4,9 @@
 package interpreter
 
 import (
+	"math/big"
 	"sort"
+	"unicode/utf8"
 )
 
 // Register built-in functions
@@ -18,9 +20,13 @@ func (e *Evaluator) registerBuiltins() {
 
 			switch arg := args[0].(type) {
 			case *String:
-				return &Integer{Value: int64(len(arg.Value))}
+				return &Integer{Value: int64(utf8.RuneCountInString(arg.Value))}
 			case *Array:
-				return &Integer{Value: int64(len(arg.Elements))}
+				return &Integer{Value: int64(arg.Len())}
+			case *Hash:
+				return &Integer{Value: int64(arg.Len())}
+			case *Range:
+				return bigResult(arg.Len())
 			default:
 				return newError("argument to `len` not supported, got %s", args[0].Type())
 			}
@@ -36,9 +42,8 @@ func (e *Evaluator) registerBuiltins() {
 				return newError("argument to `first` must be ARRAY, got %s", args[0].Type())
 			}
 
-			arr := args[0].(*Array)
-			if len(arr.Elements) > 0 {
-				return arr.Elements[0]
+			if first, ok := args[0].(*Array).Get(0); ok {
+				return first
 			}
 
 			return e.NULL
@@ -54,10 +59,8 @@ func (e *Evaluator) registerBuiltins() {
 				return newError("argument to `last` must be ARRAY, got %s", args[0].Type())
 			}
 
-			arr := args[0].(*Array)
-			length := len(arr.Elements)
-			if length > 0 {
-				return arr.Elements[length-1]
+			if last, ok := args[0].(*Array).Get(-1); ok {
+				return last
 			}
 
 			return e.NULL
@@ -73,12 +76,9 @@ func (e *Evaluator) registerBuiltins() {
 				return newError("argument to `rest` must be ARRAY, got %s", args[0].Type())
 			}
 
-			arr := args[0].(*Array)
-			length := len(arr.Elements)
-			if length > 0 {
-				newElements := make([]Object, length-1)
-				copy(newElements, arr.Elements[1:])
-				return &Array{Elements: newElements}
+			elements := args[0].(*Array).Snapshot()
+			if len(elements) > 0 {
+				return &Array{Elements: elements[1:]}
 			}
 
 			return e.NULL
@@ -94,17 +94,153 @@ func (e *Evaluator) registerBuiltins() {
 				return newError("argument to `push` must be ARRAY, got %s", args[0].Type())
 			}
 
-			arr := args[0].(*Array)
-			length := len(arr.Elements)
-
-			newElements := make([]Object, length+1)
-			copy(newElements, arr.Elements)
-			newElements[length] = args[1]
+			newElements := append(args[0].(*Array).Snapshot(), args[1])
 
 			return &Array{Elements: newElements}
 		},
 	}
 
+	e.builtins["keys"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments for keys. got=%d, want=1", len(args))
+			}
+			if args[0].Type() != HASH_OBJ {
+				return newError("argument to `keys` must be HASH, got %s", args[0].Type())
+			}
+
+			pairs := args[0].(*Hash).Entries()
+			elements := make([]Object, 0, len(pairs))
+			for _, pair := range pairs {
+				elements = append(elements, pair.Key)
+			}
+
+			return &Array{Elements: elements}
+		},
+	}
+
+	e.builtins["values"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments for values. got=%d, want=1", len(args))
+			}
+			if args[0].Type() != HASH_OBJ {
+				return newError("argument to `values` must be HASH, got %s", args[0].Type())
+			}
+
+			pairs := args[0].(*Hash).Entries()
+			elements := make([]Object, 0, len(pairs))
+			for _, pair := range pairs {
+				elements = append(elements, pair.Value)
+			}
+
+			return &Array{Elements: elements}
+		},
+	}
+
+	e.builtins["array"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments for array. got=%d, want=1", len(args))
+			}
+
+			// Collect the values a for-each loop would visit
+			elements := []Object{}
+			err := iterate(args[0], func(_, val Object) bool {
+				elements = append(elements, val)
+				return true
+			})
+			if err != nil {
+				return err
+			}
+
+			return &Array{Elements: elements}
+		},
+	}
+
+	e.builtins["next"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			if len(args) != 1 && len(args) != 2 {
+				return newError("wrong number of arguments for next. got=%d, want=1 or 2", len(args))
+			}
+			gen, ok := args[0].(*Generator)
+			if !ok {
+				return newError("argument to `next` must be GENERATOR, got %s", args[0].Type())
+			}
+
+			val, ok := gen.Next()
+			switch {
+			case ok, val != nil:
+				return val
+			case len(args) == 2:
+				// The fallback for a finished generator
+				return args[1]
+			default:
+				return e.NULL
+			}
+		},
+	}
+
+	e.builtins["has"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			if len(args) != 2 {
+				return newError("wrong number of arguments for has. got=%d, want=2", len(args))
+			}
+			if args[0].Type() != HASH_OBJ {
+				return newError("argument to `has` must be HASH, got %s", args[0].Type())
+			}
+
+			key, ok := args[1].(Hashable)
+			if !ok {
+				return newError("unusable as hash key: %s", args[1].Type())
+			}
+
+			_, found := args[0].(*Hash).Get(key)
+			return e.nativeBoolToBooleanObject(found)
+		},
+	}
+
+	e.builtins["delete"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			if len(args) != 2 {
+				return newError("wrong number of arguments for delete. got=%d, want=2", len(args))
+			}
+			if args[0].Type() != HASH_OBJ {
+				return newError("argument to `delete` must be HASH, got %s", args[0].Type())
+			}
+
+			key, ok := args[1].(Hashable)
+			if !ok {
+				return newError("unusable as hash key: %s", args[1].Type())
+			}
+
+			// Return a copy to avoid modifying the original
+			hash := args[0].(*Hash).Copy()
+			hash.Delete(key)
+
+			return hash
+		},
+	}
+
+	e.builtins["merge"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			if len(args) != 2 {
+				return newError("wrong number of arguments for merge. got=%d, want=2", len(args))
+			}
+			if args[0].Type() != HASH_OBJ || args[1].Type() != HASH_OBJ {
+				return newError("arguments to `merge` must be HASH, got %s and %s", args[0].Type(), args[1].Type())
+			}
+
+			// Keys from the second hash win on conflict
+			hash := args[0].(*Hash).Copy()
+			for _, pair := range args[1].(*Hash).Entries() {
+				hash.Set(pair.Key, pair.Value)
+			}
+
+			return hash
+		},
+	}
+
 	// Trump-specific built-ins
 	e.builtins["DEAL"] = &Builtin{
 		Fn: func(args ...Object) Object {
@@ -145,30 +281,15 @@ func (e *Evaluator) registerBuiltins() {
 				return newError("argument to TREMENDOUS_SORT must be ARRAY, got %s", args[0].Type())
 			}
 
-			array := args[0].(*Array)
-			elements := array.Elements
-			length := len(elements)
-
-			// Make a copy to avoid modifying the original
-			newElements := make([]Object, length)
-			copy(newElements, elements)
+			// Sort a copy to avoid modifying the original
+			newElements := args[0].(*Array).Snapshot()
+			length := len(newElements)
 
 			// Sort the elements
 			sort.SliceStable(newElements, func(i, j int) bool {
-				// Compare integers
-				if newElements[i].Type() == INTEGER_OBJ && newElements[j].Type() == INTEGER_OBJ {
-					return newElements[i].(*Integer).Value < newElements[j].(*Integer).Value
-				}
-				// Compare floats
-				if newElements[i].Type() == FLOAT_OBJ && newElements[j].Type() == FLOAT_OBJ {
-					return newElements[i].(*Float).Value < newElements[j].(*Float).Value
-				}
-				// Compare integer and float
-				if newElements[i].Type() == INTEGER_OBJ && newElements[j].Type() == FLOAT_OBJ {
-					return float64(newElements[i].(*Integer).Value) < newElements[j].(*Float).Value
-				}
-				if newElements[i].Type() == FLOAT_OBJ && newElements[j].Type() == INTEGER_OBJ {
-					return newElements[i].(*Float).Value < float64(newElements[j].(*Integer).Value)
+				// Compare numbers of any type
+				if cmp, ok := compareNumbers(newElements[i], newElements[j]); ok {
+					return cmp < 0
 				}
 				// Compare strings
 				if newElements[i].Type() == STRING_OBJ && newElements[j].Type() == STRING_OBJ {
@@ -200,7 +321,7 @@ func (e *Evaluator) registerBuiltins() {
 
 			// This prioritizes certain elements in an array
 			array := args[0].(*Array)
-			elements := array.Elements
+			elements := array.Snapshot()
 			length := len(elements)
 
 			if length == 0 {
@@ -231,10 +352,19 @@ func (e *Evaluator) registerBuiltins() {
 				return newError("wrong number of arguments for MAKE_IT_HUGE. got=%d, want=1", len(args))
 			}
 
-			// For numbers, multiply by 10
+			// For numbers, multiply by 10, which can overflow like any other
+			// multiplication
 			if args[0].Type() == INTEGER_OBJ {
 				val := args[0].(*Integer).Value
-				return &Integer{Value: val * 10}
+				result, overflow := mulInt64(val, 10)
+				if overflow {
+					return e.integerOverflow("*", val, 10)
+				}
+				return &Integer{Value: result}
+			}
+			if args[0].Type() == BIG_INT_OBJ {
+				val := args[0].(*BigInteger).Value
+				return bigResult(new(big.Int).Mul(val, big.NewInt(10)))
 			}
 			if args[0].Type() == FLOAT_OBJ {
 				val := args[0].(*Float).Value
@@ -250,4 +380,8 @@ func (e *Evaluator) registerBuiltins() {
 			return args[0]
 		},
 	}
+
+	e.registerStringBuiltins()
+	e.registerDecimalBuiltins()
+	e.registerTaskBuiltins()
 }
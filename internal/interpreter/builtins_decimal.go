@@ -0,0 +1,120 @@
+// file: internal/interpreter/builtins_decimal.go
+// description: Built-in functions for BILLIONS decimals in the TRUMP programming language
+
+package interpreter
+
+import "github.com/AndrewDonelson/trumplang/internal/errors"
+
+// Convert an argument of a decimal function to a decimal. A string that does
+// not hold a number raises NUMBER_FORMAT.
+func decimalArg(arg Object) (*Decimal, *Error) {
+	value, ok := toDecimal(arg)
+	if ok {
+		return value, nil
+	}
+	if _, isString := arg.(*String); isString {
+		return nil, newCodedError(errors.NUMBER_FORMAT, "cannot convert %q to BILLIONS", arg.Inspect())
+	}
+	return nil, newError("cannot convert %s to BILLIONS", arg.Inspect())
+}
+
+// Register built-in decimal functions
+func (e *Evaluator) registerDecimalBuiltins() {
+	e.builtins["decimal"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments for decimal. got=%d, want=1", len(args))
+			}
+
+			value, err := decimalArg(args[0])
+			if err != nil {
+				return err
+			}
+
+			return value
+		},
+	}
+
+	e.builtins["round"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			if len(args) != 2 && len(args) != 3 {
+				return newError("wrong number of arguments for round. got=%d, want=2 or 3", len(args))
+			}
+
+			value, err := decimalArg(args[0])
+			if err != nil {
+				return err
+			}
+
+			places, mode, err := e.roundingArgs("round", args[1:])
+			if err != nil {
+				return err
+			}
+
+			return roundDecimal(value, places, mode)
+		},
+	}
+
+	e.builtins["divide"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			if len(args) != 3 && len(args) != 4 {
+				return newError("wrong number of arguments for divide. got=%d, want=3 or 4", len(args))
+			}
+
+			left, err := decimalArg(args[0])
+			if err != nil {
+				return err
+			}
+			right, err := decimalArg(args[1])
+			if err != nil {
+				return err
+			}
+
+			places, mode, err := e.roundingArgs("divide", args[2:])
+			if err != nil {
+				return err
+			}
+
+			return divideDecimals(left, right, places, mode)
+		},
+	}
+
+	e.builtins["rounding_mode"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments for rounding_mode. got=%d, want=1", len(args))
+			}
+
+			mode, ok := args[0].(*String)
+			if !ok || !isRoundingMode(mode.Value) {
+				return newError("unknown rounding mode: %s", args[0].Inspect())
+			}
+
+			// Return the previous mode so callers can restore it
+			previous := e.rounding
+			e.rounding = mode.Value
+
+			return &String{Value: previous}
+		},
+	}
+}
+
+// Unwrap the places and optional rounding mode arguments of round and divide,
+// defaulting to the current rounding mode
+func (e *Evaluator) roundingArgs(name string, args []Object) (int, string, *Error) {
+	places, ok := args[0].(*Integer)
+	if !ok || places.Value < 0 || places.Value > 1000 {
+		return 0, "", newError("decimal places for `%s` must be an INTEGER from 0 to 1000, got %s", name, args[0].Inspect())
+	}
+
+	mode := e.rounding
+	if len(args) > 1 {
+		modeArg, ok := args[1].(*String)
+		if !ok || !isRoundingMode(modeArg.Value) {
+			return 0, "", newError("unknown rounding mode: %s", args[1].Inspect())
+		}
+		mode = modeArg.Value
+	}
+
+	return int(places.Value), mode, nil
+}
@@ -0,0 +1,198 @@
+// file: internal/interpreter/builtins_strings.go
+// description: Built-in string functions for the TRUMP programming language
+
+package interpreter
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Register built-in string functions. Positions and lengths are measured in
+// runes, never bytes.
+func (e *Evaluator) registerStringBuiltins() {
+	e.builtins["upper"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			strs, err := stringArgs("upper", args, 1)
+			if err != nil {
+				return err
+			}
+			return &String{Value: strings.ToUpper(strs[0])}
+		},
+	}
+
+	e.builtins["lower"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			strs, err := stringArgs("lower", args, 1)
+			if err != nil {
+				return err
+			}
+			return &String{Value: strings.ToLower(strs[0])}
+		},
+	}
+
+	e.builtins["trim"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			// An optional second argument lists the characters to trim
+			if len(args) == 2 {
+				strs, err := stringArgs("trim", args, 2)
+				if err != nil {
+					return err
+				}
+				return &String{Value: strings.Trim(strs[0], strs[1])}
+			}
+
+			strs, err := stringArgs("trim", args, 1)
+			if err != nil {
+				return err
+			}
+			return &String{Value: strings.TrimSpace(strs[0])}
+		},
+	}
+
+	e.builtins["split"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			strs, err := stringArgs("split", args, 2)
+			if err != nil {
+				return err
+			}
+
+			// An empty separator splits into characters
+			parts := strings.Split(strs[0], strs[1])
+			elements := make([]Object, len(parts))
+			for i, part := range parts {
+				elements[i] = &String{Value: part}
+			}
+
+			return &Array{Elements: elements}
+		},
+	}
+
+	e.builtins["join"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			if len(args) != 2 {
+				return newError("wrong number of arguments for join. got=%d, want=2", len(args))
+			}
+			if args[0].Type() != ARRAY_OBJ || args[1].Type() != STRING_OBJ {
+				return newError("arguments to `join` must be ARRAY and STRING, got %s and %s", args[0].Type(), args[1].Type())
+			}
+
+			// Non-string elements are joined in their printed form
+			elements := args[0].(*Array).Snapshot()
+			parts := make([]string, len(elements))
+			for i, el := range elements {
+				parts[i] = el.Inspect()
+			}
+
+			return &String{Value: strings.Join(parts, args[1].(*String).Value)}
+		},
+	}
+
+	e.builtins["replace"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			strs, err := stringArgs("replace", args, 3)
+			if err != nil {
+				return err
+			}
+			return &String{Value: strings.ReplaceAll(strs[0], strs[1], strs[2])}
+		},
+	}
+
+	e.builtins["contains"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			strs, err := stringArgs("contains", args, 2)
+			if err != nil {
+				return err
+			}
+			return e.nativeBoolToBooleanObject(strings.Contains(strs[0], strs[1]))
+		},
+	}
+
+	e.builtins["starts_with"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			strs, err := stringArgs("starts_with", args, 2)
+			if err != nil {
+				return err
+			}
+			return e.nativeBoolToBooleanObject(strings.HasPrefix(strs[0], strs[1]))
+		},
+	}
+
+	e.builtins["ends_with"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			strs, err := stringArgs("ends_with", args, 2)
+			if err != nil {
+				return err
+			}
+			return e.nativeBoolToBooleanObject(strings.HasSuffix(strs[0], strs[1]))
+		},
+	}
+
+	e.builtins["index_of"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			strs, err := stringArgs("index_of", args, 2)
+			if err != nil {
+				return err
+			}
+
+			// Convert the byte offset into a character offset
+			i := strings.Index(strs[0], strs[1])
+			if i < 0 {
+				return &Integer{Value: -1}
+			}
+			return &Integer{Value: int64(utf8.RuneCountInString(strs[0][:i]))}
+		},
+	}
+
+	e.builtins["repeat"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			if len(args) != 2 {
+				return newError("wrong number of arguments for repeat. got=%d, want=2", len(args))
+			}
+			if args[0].Type() != STRING_OBJ || args[1].Type() != INTEGER_OBJ {
+				return newError("arguments to `repeat` must be STRING and INTEGER, got %s and %s", args[0].Type(), args[1].Type())
+			}
+
+			count := args[1].(*Integer).Value
+			if count < 0 {
+				return newError("repeat count must not be negative, got %d", count)
+			}
+
+			return &String{Value: strings.Repeat(args[0].(*String).Value, int(count))}
+		},
+	}
+
+	e.builtins["chars"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			strs, err := stringArgs("chars", args, 1)
+			if err != nil {
+				return err
+			}
+
+			elements := []Object{}
+			for _, r := range strs[0] {
+				elements = append(elements, &String{Value: string(r)})
+			}
+
+			return &Array{Elements: elements}
+		},
+	}
+}
+
+// Check that a builtin received exactly want STRING arguments and unwrap them
+func stringArgs(name string, args []Object, want int) ([]string, *Error) {
+	if len(args) != want {
+		return nil, newError("wrong number of arguments for %s. got=%d, want=%d", name, len(args), want)
+	}
+
+	strs := make([]string, len(args))
+	for i, arg := range args {
+		str, ok := arg.(*String)
+		if !ok {
+			return nil, newError("argument to `%s` must be STRING, got %s", name, arg.Type())
+		}
+		strs[i] = str.Value
+	}
+
+	return strs, nil
+}
@@ -0,0 +1,16 @@
+// file: internal/interpreter/builtins_strings_test.go
+// description: Tests for the built-in string functions
+
+package interpreter
+
+import "testing"
+
+func TestStringBuiltins(t *testing.T) {
+	runEvalTests(t, []evalTest{
+		{"upper", `upper("wall");`, "WALL"},
+		{"split", `split("a,b,c", ",");`, "[a, b, c]"},
+		{"join", `join(["a", 1], "-");`, "a-1"},
+		{"join after split", `join(split("a b", " "), "+");`, "a+b"},
+		{"characters", `[len("héllo"), index_of("héllo", "l")];`, "[5, 2]"},
+	})
+}
@@ -0,0 +1,145 @@
+// file: internal/interpreter/builtins_tasks.go
+// description: Built-in functions for tasks and channels in the TRUMP programming language
+
+package interpreter
+
+import "github.com/AndrewDonelson/trumplang/internal/errors"
+
+// Register built-in task and channel functions
+func (e *Evaluator) registerTaskBuiltins() {
+	e.builtins["await"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments for await. got=%d, want=1", len(args))
+			}
+
+			switch arg := args[0].(type) {
+			case *Task:
+				return arg.Wait()
+			case *Array:
+				// Wait for every task before reporting the first failure
+				tasks := arg.Snapshot()
+				results := make([]Object, len(tasks))
+				for i, el := range tasks {
+					task, ok := el.(*Task)
+					if !ok {
+						return newError("argument to `await` must be TASK or ARRAY of TASK, got %s in ARRAY", el.Type())
+					}
+					results[i] = task.Wait()
+					if err, ok := results[i].(*Error); ok && err.Code == errors.DEADLOCK {
+						return err
+					}
+				}
+				for _, result := range results {
+					if IsError(result) {
+						return result
+					}
+				}
+				return &Array{Elements: results}
+			default:
+				return newError("argument to `await` must be TASK or ARRAY of TASK, got %s", args[0].Type())
+			}
+		},
+	}
+
+	e.builtins["channel"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			if len(args) > 1 {
+				return newError("wrong number of arguments for channel. got=%d, want=0 or 1", len(args))
+			}
+
+			capacity := int64(0)
+			if len(args) == 1 {
+				size, ok := args[0].(*Integer)
+				if !ok || size.Value < 0 || size.Value > 1_000_000 {
+					return newError("capacity for `channel` must be an INTEGER from 0 to 1000000, got %s", args[0].Inspect())
+				}
+				capacity = size.Value
+			}
+
+			return &Channel{ch: make(chan Object, capacity), tasks: e.tasks}
+		},
+	}
+
+	e.builtins["send"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			if len(args) != 2 {
+				return newError("wrong number of arguments for send. got=%d, want=2", len(args))
+			}
+			ch, ok := args[0].(*Channel)
+			if !ok {
+				return newError("argument to `send` must be CHANNEL, got %s", args[0].Type())
+			}
+
+			if err := ch.Send(args[1]); err != nil {
+				return err
+			}
+			return e.NULL
+		},
+	}
+
+	e.builtins["receive"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			if len(args) != 1 && len(args) != 2 {
+				return newError("wrong number of arguments for receive. got=%d, want=1 or 2", len(args))
+			}
+			ch, ok := args[0].(*Channel)
+			if !ok {
+				return newError("argument to `receive` must be CHANNEL, got %s", args[0].Type())
+			}
+
+			val, ok, err := ch.Receive()
+			switch {
+			case err != nil:
+				return err
+			case ok:
+				return val
+			case len(args) == 2:
+				// The fallback for a closed channel
+				return args[1]
+			default:
+				return e.NULL
+			}
+		},
+	}
+
+	e.builtins["close"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments for close. got=%d, want=1", len(args))
+			}
+			ch, ok := args[0].(*Channel)
+			if !ok {
+				return newError("argument to `close` must be CHANNEL, got %s", args[0].Type())
+			}
+
+			if err := ch.Close(); err != nil {
+				return err
+			}
+			return e.NULL
+		},
+	}
+
+	e.builtins["select"] = &Builtin{
+		Fn: func(args ...Object) Object {
+			if len(args) != 1 && len(args) != 2 {
+				return newError("wrong number of arguments for select. got=%d, want=1 or 2", len(args))
+			}
+			cases, ok := args[0].(*Array)
+			if !ok {
+				return newError("argument to `select` must be ARRAY, got %s", args[0].Type())
+			}
+
+			// With a fallback, select does not wait
+			chosen, val, err := e.selectChannels(cases.Snapshot(), len(args) == 1)
+			switch {
+			case err != nil:
+				return err
+			case chosen < 0:
+				return args[1]
+			default:
+				return &Array{Elements: []Object{&Integer{Value: int64(chosen)}, val}}
+			}
+		},
+	}
+}
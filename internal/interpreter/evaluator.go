@@ -6,14 +6,21 @@ package interpreter
 import (
 	"fmt"
 	"math/rand"
+	"sync"
 	"time"
 
+	"github.com/AndrewDonelson/trumplang/internal/errors"
 	"github.com/AndrewDonelson/trumplang/internal/parser"
 )
 
 // Create a new error
 func newError(format string, a ...interface{}) *Error {
-	return &Error{Message: fmt.Sprintf(format, a...)}
+	return newCodedError(errors.RUNTIME_ERROR, format, a...)
+}
+
+// Create a new error with a specific error code from the errors package
+func newCodedError(code string, format string, a ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, a...)}
 }
 
 // Unwrap a return value
@@ -38,17 +45,47 @@ type Evaluator struct {
 
 	// Built-in functions
 	builtins map[string]Object
+
+	// File being evaluated, used to resolve relative imports
+	file string
+	// Loaded modules keyed by absolute path, shared by every task
+	modules    map[string]*Module
+	moduleLock *sync.Mutex
+	// Tasks of the program, shared by every task to find deadlocks
+	tasks *taskGroup
+	// Absolute paths of the files currently being loaded, for cycle detection
+	importStack []string
+
+	// Strict mode makes out-of-range index reads errors instead of COVFEFE
+	strict bool
+	// BIGLY mode promotes overflowing integer arithmetic to BigInteger. It
+	// follows the file being evaluated and the file each function came from.
+	bigly bool
+	// Rounding mode for BILLIONS division
+	rounding string
+	// Hands a value to the reader of the generator this evaluator is
+	// running, reporting false if the generator has been stopped
+	yield func(Object) bool
+}
+
+// SetStrict enables or disables strict mode
+func (e *Evaluator) SetStrict(strict bool) {
+	e.strict = strict
 }
 
 // NewEvaluator creates a new Evaluator
 func NewEvaluator() *Evaluator {
 	e := &Evaluator{
-		env:      NewEnvironment(),
-		TRUE:     &Boolean{Value: true},
-		FALSE:    &Boolean{Value: false},
-		NULL:     &Null{},
-		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
-		builtins: make(map[string]Object),
+		env:        NewEnvironment(),
+		TRUE:       &Boolean{Value: true},
+		FALSE:      &Boolean{Value: false},
+		NULL:       &Null{},
+		rand:       rand.New(rand.NewSource(time.Now().UnixNano())),
+		builtins:   make(map[string]Object),
+		modules:    make(map[string]*Module),
+		moduleLock: &sync.Mutex{},
+		tasks:      newTaskGroup(),
+		rounding:   ROUND_HALF_EVEN,
 	}
 
 	// Register built-in functions
@@ -57,6 +94,23 @@ func NewEvaluator() *Evaluator {
 	return e
 }
 
+// Copy the evaluator to run code apart from it, in a concurrent task or a
+// suspended generator body. The copy starts in env and has its own random
+// numbers, rounding mode and built-ins bound to it; only the module cache
+// and the count of tasks are shared.
+func (e *Evaluator) fork(env *Environment) *Evaluator {
+	child := *e
+	child.env = env
+	child.rand = rand.New(rand.NewSource(e.rand.Int63()))
+	child.importStack = append([]string(nil), e.importStack...)
+	child.yield = nil
+
+	child.builtins = make(map[string]Object)
+	child.registerBuiltins()
+
+	return &child
+}
+
 // Eval evaluates a node
 func (e *Evaluator) Eval(node parser.Node) Object {
 	switch node := node.(type) {
@@ -72,8 +126,15 @@ func (e *Evaluator) Eval(node parser.Node) Object {
 		if IsError(val) {
 			return val
 		}
-		e.env.Set(node.Name.Value, val)
+		if err := checkVariableAnnotation(node.Name.Value, node.Type, val); err != nil {
+			return err
+		}
+		if !e.env.Declare(node.Name.Value, val, node.Constant(), node) {
+			return newCodedError(errors.REDECLARATION, "%s is already declared in this scope", node.Name.Value)
+		}
 		return val // Return the value for chaining
+	case *parser.FunctionDeclaration:
+		return e.evalFunctionDeclaration(node)
 	case *parser.ReturnStatement:
 		val := e.Eval(node.ReturnValue)
 		if IsError(val) {
@@ -81,11 +142,35 @@ func (e *Evaluator) Eval(node parser.Node) Object {
 		}
 		return &ReturnValue{Value: val}
 	case *parser.IfStatement:
-		return e.evalIfStatement(node)
+		return e.evalIf(node.Condition, node.Consequence, node.Alternative)
+	case *parser.IfExpression:
+		return e.evalIf(node.Condition, node.Consequence, node.Alternative)
 	case *parser.WhileStatement:
 		return e.evalWhileStatement(node)
 	case *parser.ForStatement:
 		return e.evalForStatement(node)
+	case *parser.ForEachStatement:
+		return e.evalForEachStatement(node)
+	case *parser.BreakStatement:
+		if node.Label != nil {
+			return &Break{Label: node.Label.Value}
+		}
+		return &Break{}
+	case *parser.ContinueStatement:
+		if node.Label != nil {
+			return &Continue{Label: node.Label.Value}
+		}
+		return &Continue{}
+	case *parser.ImportStatement:
+		return e.evalImportStatement(node)
+	case *parser.ExportStatement:
+		return e.Eval(node.Declaration)
+	case *parser.ThrowStatement:
+		return e.evalThrowStatement(node)
+	case *parser.YieldStatement:
+		return e.evalYieldStatement(node)
+	case *parser.TryStatement:
+		return e.evalTryStatement(node)
 	case *parser.TweetStatement:
 		return e.evalTweetStatement(node)
 	case *parser.RallyStatement:
@@ -94,12 +179,22 @@ func (e *Evaluator) Eval(node parser.Node) Object {
 		return e.evalExecutiveOrderStatement(node)
 
 	// Expressions
+	case *parser.DecimalLiteral:
+		value, ok := parseDecimal(node.Value)
+		if !ok {
+			return newError("invalid BILLIONS literal: %s", node.Value)
+		}
+		return value
+	case *parser.BigIntegerLiteral:
+		return &BigInteger{Value: node.Value}
 	case *parser.IntegerLiteral:
 		return &Integer{Value: node.Value}
 	case *parser.FloatLiteral:
 		return &Float{Value: node.Value}
 	case *parser.StringLiteral:
 		return &String{Value: node.Value}
+	case *parser.InterpolatedString:
+		return e.evalInterpolatedString(node)
 	case *parser.BooleanLiteral:
 		return e.nativeBoolToBooleanObject(node.Value)
 	case *parser.PrefixExpression:
@@ -109,6 +204,10 @@ func (e *Evaluator) Eval(node parser.Node) Object {
 		}
 		return e.evalPrefixExpression(node.Operator, right)
 	case *parser.InfixExpression:
+		if node.Operator == "&&" || node.Operator == "||" {
+			return e.evalLogicalExpression(node)
+		}
+
 		left := e.Eval(node.Left)
 		if IsError(left) {
 			return left
@@ -120,14 +219,33 @@ func (e *Evaluator) Eval(node parser.Node) Object {
 		}
 
 		return e.evalInfixExpression(node.Operator, left, right)
+	case *parser.RangeExpression:
+		return e.evalRangeExpression(node)
+	case *parser.SpawnExpression:
+		return e.evalSpawnExpression(node)
+	case *parser.ConditionalExpression:
+		condition := e.Eval(node.Condition)
+		if IsError(condition) {
+			return condition
+		}
+		if IsTruthy(condition) {
+			return e.Eval(node.Consequence)
+		}
+		return e.Eval(node.Alternative)
 	case *parser.Identifier:
 		return e.evalIdentifier(node)
+	case *parser.AssignExpression:
+		return e.evalAssignExpression(node)
 	case *parser.ArrayLiteral:
 		elements := e.evalExpressions(node.Elements)
 		if len(elements) == 1 && IsError(elements[0]) {
 			return elements[0]
 		}
 		return &Array{Elements: elements}
+	case *parser.HashLiteral:
+		return e.evalHashLiteral(node)
+	case *parser.MatchExpression:
+		return e.evalMatchExpression(node)
 	case *parser.IndexExpression:
 		left := e.Eval(node.Left)
 		if IsError(left) {
@@ -138,11 +256,24 @@ func (e *Evaluator) Eval(node parser.Node) Object {
 			return index
 		}
 		return e.evalIndexExpression(left, index)
+	case *parser.SliceExpression:
+		return e.evalSliceExpression(node)
 	case *parser.FunctionLiteral:
 		params := node.Parameters
 		body := node.Body
 		rating := node.Rating
-		return &Function{Parameters: params, Body: body, Env: e.env, Rating: rating}
+		return &Function{
+			Name:           node.Name,
+			Parameters:     params,
+			Body:           body,
+			Env:            e.env,
+			Rating:         rating,
+			Bigly:          e.bigly,
+			Lambda:         node.Lambda,
+			Generator:      node.Generator,
+			ParameterTypes: node.ParameterTypes,
+			ReturnType:     node.ReturnType,
+		}
 	case *parser.CallExpression:
 		function := e.Eval(node.Function)
 		if IsError(function) {
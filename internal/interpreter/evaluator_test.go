@@ -0,0 +1,48 @@
+// file: internal/interpreter/evaluator_test.go
+// description: Helpers shared by the evaluator tests
+
+package interpreter
+
+import (
+	"testing"
+
+	"github.com/AndrewDonelson/trumplang/internal/lexer"
+	"github.com/AndrewDonelson/trumplang/internal/parser"
+)
+
+// evalTest pairs a program with the Inspect output of its last value
+type evalTest struct {
+	name     string
+	input    string
+	expected string
+}
+
+// Parse and evaluate a program, failing the test on parse errors
+func testEval(t *testing.T, input string) Object {
+	t.Helper()
+
+	p := parser.New(lexer.New(input))
+	program := p.Parse()
+	if errs := p.Errors(); len(errs) > 0 {
+		t.Fatalf("parse errors: %v", errs)
+	}
+
+	return NewEvaluator().Eval(program)
+}
+
+// Run each program and compare the Inspect output of its result
+func runEvalTests(t *testing.T, tests []evalTest) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := testEval(t, tt.input)
+			if result == nil {
+				t.Fatalf("program returned nil, want %q", tt.expected)
+			}
+			if got := result.Inspect(); got != tt.expected {
+				t.Errorf("got %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -3,6 +3,15 @@
 
 package interpreter
 
+import (
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/AndrewDonelson/trumplang/internal/errors"
+	"github.com/AndrewDonelson/trumplang/internal/parser"
+)
+
 // Evaluate a prefix expression
 func (e *Evaluator) evalPrefixExpression(operator string, right Object) Object {
 	switch operator {
@@ -10,6 +19,8 @@ func (e *Evaluator) evalPrefixExpression(operator string, right Object) Object {
 		return e.evalBangOperatorExpression(right)
 	case "-":
 		return e.evalMinusPrefixOperatorExpression(right)
+	case "~":
+		return e.evalTildePrefixOperatorExpression(right)
 	default:
 		return newError("unknown operator: %s%s", operator, right.Type())
 	}
@@ -34,20 +45,70 @@ func (e *Evaluator) evalMinusPrefixOperatorExpression(right Object) Object {
 	switch right.Type() {
 	case INTEGER_OBJ:
 		value := right.(*Integer).Value
+		if value == math.MinInt64 {
+			return e.integerOverflow("-", 0, value)
+		}
 		return &Integer{Value: -value}
+	case BIG_INT_OBJ:
+		value := right.(*BigInteger).Value
+		return bigResult(new(big.Int).Neg(value))
 	case FLOAT_OBJ:
 		value := right.(*Float).Value
 		return &Float{Value: -value}
+	case DECIMAL_OBJ:
+		value := right.(*Decimal)
+		return &Decimal{Value: new(big.Int).Neg(value.Value), Scale: value.Scale}
 	default:
 		return newError("unknown operator: -%s", right.Type())
 	}
 }
 
+// Evaluate a bitwise complement expression
+func (e *Evaluator) evalTildePrefixOperatorExpression(right Object) Object {
+	switch right := right.(type) {
+	case *Integer:
+		return &Integer{Value: ^right.Value}
+	case *BigInteger:
+		return bigResult(new(big.Int).Not(right.Value))
+	default:
+		return newError("unknown operator: ~%s", right.Type())
+	}
+}
+
+// Evaluate a short-circuiting && or || expression. The right operand is
+// only evaluated when the left one does not already decide the result.
+func (e *Evaluator) evalLogicalExpression(node *parser.InfixExpression) Object {
+	left := e.Eval(node.Left)
+	if IsError(left) {
+		return left
+	}
+
+	if node.Operator == "&&" && !IsTruthy(left) {
+		return e.FALSE
+	}
+	if node.Operator == "||" && IsTruthy(left) {
+		return e.TRUE
+	}
+
+	right := e.Eval(node.Right)
+	if IsError(right) {
+		return right
+	}
+
+	return e.nativeBoolToBooleanObject(IsTruthy(right))
+}
+
 // Evaluate an infix expression
 func (e *Evaluator) evalInfixExpression(operator string, left, right Object) Object {
 	switch {
+	case operator == "IN":
+		return e.evalMembership(left, right)
 	case left.Type() == INTEGER_OBJ && right.Type() == INTEGER_OBJ:
 		return e.evalIntegerInfixExpression(operator, left, right)
+	case (left.Type() == DECIMAL_OBJ || right.Type() == DECIMAL_OBJ) && isNumber(left) && isNumber(right):
+		return e.evalDecimalInfixExpression(operator, left, right)
+	case (left.Type() == BIG_INT_OBJ || right.Type() == BIG_INT_OBJ) && isNumber(left) && isNumber(right):
+		return e.evalMixedBigIntegerInfixExpression(operator, left, right)
 	case left.Type() == FLOAT_OBJ && right.Type() == FLOAT_OBJ:
 		return e.evalFloatInfixExpression(operator, left, right)
 	case left.Type() == INTEGER_OBJ && right.Type() == FLOAT_OBJ:
@@ -58,13 +119,13 @@ func (e *Evaluator) evalInfixExpression(operator string, left, right Object) Obj
 		return e.evalFloatInfixExpression(operator, left, &Float{Value: intValue})
 	case left.Type() == STRING_OBJ && right.Type() == STRING_OBJ:
 		return e.evalStringInfixExpression(operator, left, right)
-	case left.Type() == STRING_OBJ && (right.Type() == INTEGER_OBJ || right.Type() == FLOAT_OBJ || right.Type() == BOOLEAN_OBJ || right.Type() == ARRAY_OBJ):
+	case left.Type() == STRING_OBJ && (right.Type() == INTEGER_OBJ || right.Type() == BIG_INT_OBJ || right.Type() == DECIMAL_OBJ || right.Type() == FLOAT_OBJ || right.Type() == BOOLEAN_OBJ || right.Type() == ARRAY_OBJ || right.Type() == HASH_OBJ):
 		// Allow string concatenation with other types
 		if operator == "+" {
 			return &String{Value: left.(*String).Value + right.Inspect()}
 		}
 		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
-	case (left.Type() == INTEGER_OBJ || left.Type() == FLOAT_OBJ || left.Type() == BOOLEAN_OBJ || left.Type() == ARRAY_OBJ) && right.Type() == STRING_OBJ:
+	case (left.Type() == INTEGER_OBJ || left.Type() == BIG_INT_OBJ || left.Type() == DECIMAL_OBJ || left.Type() == FLOAT_OBJ || left.Type() == BOOLEAN_OBJ || left.Type() == ARRAY_OBJ || left.Type() == HASH_OBJ) && right.Type() == STRING_OBJ:
 		// Allow string concatenation with other types
 		if operator == "+" {
 			return &String{Value: left.Inspect() + right.(*String).Value}
@@ -75,29 +136,74 @@ func (e *Evaluator) evalInfixExpression(operator string, left, right Object) Obj
 	case operator == "!=":
 		return e.nativeBoolToBooleanObject(left != right)
 	case left.Type() != right.Type():
-		return newError("type mismatch: %s %s %s", left.Type(), operator, right.Type())
+		return newCodedError(errors.TYPE_MISMATCH, "type mismatch: %s %s %s", left.Type(), operator, right.Type())
 	default:
 		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
 	}
 }
 
-// Evaluate an integer infix expression
+// Evaluate an integer infix expression. Results that do not fit in 64 bits
+// are handed to integerOverflow.
 func (e *Evaluator) evalIntegerInfixExpression(operator string, left, right Object) Object {
 	leftVal := left.(*Integer).Value
 	rightVal := right.(*Integer).Value
 
 	switch operator {
 	case "+":
-		return &Integer{Value: leftVal + rightVal}
+		if result, overflow := addInt64(leftVal, rightVal); !overflow {
+			return &Integer{Value: result}
+		}
+		return e.integerOverflow(operator, leftVal, rightVal)
 	case "-":
-		return &Integer{Value: leftVal - rightVal}
+		if result, overflow := subInt64(leftVal, rightVal); !overflow {
+			return &Integer{Value: result}
+		}
+		return e.integerOverflow(operator, leftVal, rightVal)
 	case "*":
-		return &Integer{Value: leftVal * rightVal}
+		if result, overflow := mulInt64(leftVal, rightVal); !overflow {
+			return &Integer{Value: result}
+		}
+		return e.integerOverflow(operator, leftVal, rightVal)
 	case "/":
 		if rightVal == 0 {
-			return newError("division by zero")
+			return newCodedError(errors.DIVISION_BY_ZERO, "division by zero")
+		}
+		if leftVal == math.MinInt64 && rightVal == -1 {
+			return e.integerOverflow(operator, leftVal, rightVal)
 		}
 		return &Integer{Value: leftVal / rightVal}
+	case "%":
+		if rightVal == 0 {
+			return newCodedError(errors.DIVISION_BY_ZERO, "division by zero")
+		}
+		return &Integer{Value: leftVal % rightVal}
+	case "**":
+		if rightVal < 0 {
+			return &Float{Value: math.Pow(float64(leftVal), float64(rightVal))}
+		}
+		if result, overflow := powInt64(leftVal, rightVal); !overflow {
+			return &Integer{Value: result}
+		}
+		return e.integerOverflow(operator, leftVal, rightVal)
+	case "&":
+		return &Integer{Value: leftVal & rightVal}
+	case "|":
+		return &Integer{Value: leftVal | rightVal}
+	case "^":
+		return &Integer{Value: leftVal ^ rightVal}
+	case "<<":
+		if rightVal < 0 {
+			return newError("negative shift count: %d", rightVal)
+		}
+		if result, overflow := shlInt64(leftVal, rightVal); !overflow {
+			return &Integer{Value: result}
+		}
+		return e.integerOverflow(operator, leftVal, rightVal)
+	case ">>":
+		if rightVal < 0 {
+			return newError("negative shift count: %d", rightVal)
+		}
+		return &Integer{Value: leftVal >> rightVal}
 	case "<":
 		return e.nativeBoolToBooleanObject(leftVal < rightVal)
 	case ">":
@@ -129,9 +235,16 @@ func (e *Evaluator) evalFloatInfixExpression(operator string, left, right Object
 		return &Float{Value: leftVal * rightVal}
 	case "/":
 		if rightVal == 0 {
-			return newError("division by zero")
+			return newCodedError(errors.DIVISION_BY_ZERO, "division by zero")
 		}
 		return &Float{Value: leftVal / rightVal}
+	case "%":
+		if rightVal == 0 {
+			return newCodedError(errors.DIVISION_BY_ZERO, "division by zero")
+		}
+		return &Float{Value: math.Mod(leftVal, rightVal)}
+	case "**":
+		return &Float{Value: math.Pow(leftVal, rightVal)}
 	case "<":
 		return e.nativeBoolToBooleanObject(leftVal < rightVal)
 	case ">":
@@ -149,6 +262,27 @@ func (e *Evaluator) evalFloatInfixExpression(operator string, left, right Object
 	}
 }
 
+// Evaluate an interpolated string. Errors from an embedded expression are
+// tagged with the position of its placeholder.
+func (e *Evaluator) evalInterpolatedString(is *parser.InterpolatedString) Object {
+	var out strings.Builder
+
+	for _, part := range is.Parts {
+		switch part := part.(type) {
+		case *parser.StringLiteral:
+			out.WriteString(part.Value)
+		case *parser.Placeholder:
+			val := e.Eval(part.Value)
+			if err, ok := val.(*Error); ok {
+				return newCodedError(err.Code, "%s (in string interpolation at %d:%d)", err.Message, part.Token.Line, part.Token.Column)
+			}
+			out.WriteString(val.Inspect())
+		}
+	}
+
+	return &String{Value: out.String()}
+}
+
 // Evaluate a string infix expression
 func (e *Evaluator) evalStringInfixExpression(operator string, left, right Object) Object {
 	leftVal := left.(*String).Value
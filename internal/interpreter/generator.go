@@ -0,0 +1,114 @@
+// file: internal/interpreter/generator.go
+// description: Generators and the YIELD statement for the TRUMP programming language
+
+package interpreter
+
+import (
+	"iter"
+	"runtime"
+	"sync"
+
+	"github.com/AndrewDonelson/trumplang/internal/parser"
+)
+
+// Generator is a call of a function containing YIELD. Its body runs in
+// steps, each one up to the next YIELD, as values are asked for.
+type Generator struct {
+	Function *Function
+	state    *generatorState
+	running  sync.Mutex // Held while the body runs, so only one reader resumes it at a time
+}
+
+// generatorState is the part of a generator its suspended body refers to.
+// It is kept apart so that the body does not keep the Generator alive, and
+// a generator nobody refers to any more can be collected and stopped.
+type generatorState struct {
+	next func() (Object, bool)
+	stop func()
+	err  *Error // Error the body finished with, until it is reported
+}
+
+func (g *Generator) Type() string { return GENERATOR_OBJ }
+func (g *Generator) Inspect() string {
+	if g.Function.Name != "" {
+		return "GENERATOR " + g.Function.Name
+	}
+	return "GENERATOR"
+}
+
+// Start a call of a generator function. Nothing in its body runs until the
+// first value is asked for.
+func (e *Evaluator) newGenerator(fn *Function, args []Object) *Generator {
+	state := &generatorState{}
+
+	// The body is suspended part way through, so it needs an evaluator of
+	// its own to keep track of where it is
+	body := e.fork(e.extendFunctionEnv(fn, args))
+	body.bigly = fn.Bigly
+
+	state.next, state.stop = iter.Pull(func(yield func(Object) bool) {
+		body.yield = yield
+		if err, ok := body.Eval(fn.Body).(*Error); ok {
+			state.err = err
+		}
+	})
+
+	// A generator dropped before it finishes, such as one only read with
+	// next(), would otherwise leave its body suspended for good. Stopping it
+	// runs its ANYWAY blocks, which must not hold up other finalizers.
+	gen := &Generator{Function: fn, state: state}
+	runtime.SetFinalizer(gen, func(gen *Generator) {
+		go gen.state.stop()
+	})
+
+	return gen
+}
+
+// Next runs the generator up to its next YIELD and returns the value it
+// yields. Once the body has finished it returns false, along with the error
+// that finished it, if any. A generator already being resumed, by its own
+// body or another task, cannot be resumed again until it yields.
+func (g *Generator) Next() (Object, bool) {
+	if !g.running.TryLock() {
+		return newError("%s is already running", g.Inspect()), false
+	}
+	defer g.running.Unlock()
+
+	val, ok := g.state.next()
+	if ok {
+		return val, true
+	}
+
+	// Report an error only once, like any other
+	if err := g.state.err; err != nil {
+		g.state.err = nil
+		return err, false
+	}
+	return nil, false
+}
+
+// Stop finishes a generator early. The body returns from the YIELD it is
+// suspended at, running any ANYWAY blocks on the way out.
+func (g *Generator) Stop() {
+	if g.running.TryLock() {
+		defer g.running.Unlock()
+		g.state.stop()
+		g.state.err = nil
+	}
+}
+
+// Evaluate a yield statement, suspending the generator until the next value
+// is asked for
+func (e *Evaluator) evalYieldStatement(ys *parser.YieldStatement) Object {
+	val := e.Eval(ys.Value)
+	if IsError(val) {
+		return val
+	}
+
+	// Unwind the body like a RETURN if the generator has been stopped
+	if !e.yield(val) {
+		return &ReturnValue{Value: e.NULL}
+	}
+
+	return e.NULL
+}
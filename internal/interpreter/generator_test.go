@@ -0,0 +1,104 @@
+// file: internal/interpreter/generator_test.go
+// description: Tests for generators and the YIELD statement
+
+package interpreter
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+// A generator counting from 1 to n
+const countGenerator = `
+YUGE FUNCTION count(n) { YUGE i = 1; MAKE DEALS WHILE (i <= n) { YIELD i; i = i + 1; } }
+`
+
+func TestGenerators(t *testing.T) {
+	runEvalTests(t, []evalTest{
+		{
+			"next",
+			countGenerator + `YUGE g = count(2);
+			[next(g), next(g), next(g), next(g, "done")];`,
+			"[1, 2, COVFEFE, done]",
+		},
+		{
+			"nested loops",
+			countGenerator + `YUGE pairs = [];
+			MAKE AMERICA GREAT AGAIN FOR (a IN count(2)) {
+				MAKE AMERICA GREAT AGAIN FOR (b IN count(a)) { pairs = push(pairs, a * 10 + b); }
+			}
+			pairs;`,
+			"[11, 21, 22]",
+		},
+		{
+			"generator reading a generator",
+			countGenerator + `YUGE FUNCTION doubled() { MAKE AMERICA GREAT AGAIN FOR (x IN count(3)) { YIELD x * 2; } }
+			array(doubled());`,
+			"[2, 4, 6]",
+		},
+		{
+			// No BUILD WALL IF here, since IF conditions are sometimes flipped
+			"early exit runs ANYWAY",
+			`YUGE log = [];
+			YUGE FUNCTION guarded() { DENY { YIELD 1; YIELD 2; YIELD 3; } ANYWAY { log = push(log, "cleanup"); } }
+			MAKE AMERICA GREAT AGAIN FOR (x IN guarded()) {
+				log = push(log, x);
+				YOU'RE FIRED;
+			}
+			log;`,
+			"[1, cleanup]",
+		},
+		{
+			"error in a loop",
+			`YUGE FUNCTION broken() { YIELD 1; FAKE_NEWS "rigged"; }
+			YUGE msg = "";
+			DENY { MAKE AMERICA GREAT AGAIN FOR (x IN broken()) { } } BLAME (err) { msg = err["message"]; }
+			msg;`,
+			"rigged",
+		},
+		{
+			"error from next is reported once",
+			`YUGE FUNCTION broken() { YIELD 1; FAKE_NEWS "rigged"; }
+			YUGE b = broken();
+			YUGE msg = "";
+			next(b);
+			DENY { next(b); } BLAME (err) { msg = err["message"]; }
+			[msg, next(b, "finished")];`,
+			"[rigged, finished]",
+		},
+		{
+			"re-entry",
+			`YUGE self = 0;
+			YUGE FUNCTION again() { YIELD next(self); }
+			self = again();
+			YUGE msg = "";
+			DENY { next(self); } BLAME (err) { msg = err["message"]; }
+			msg;`,
+			"GENERATOR again is already running",
+		},
+	})
+}
+
+func TestDroppedGeneratorsAreStopped(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	result := testEval(t, `
+	YUGE FUNCTION forever() { YUGE i = 0; MAKE DEALS WHILE (WINNING) { YIELD i; i = i + 1; } }
+	MAKE AMERICA GREAT AGAIN FOR (i IN 1..50) { next(forever()); }
+	`)
+	if IsError(result) {
+		t.Fatalf("program failed: %s", result.Inspect())
+	}
+
+	// Finalizers run after a collection, and the bodies they stop finish
+	// on goroutines of their own
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > before+5 {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d goroutines still running, started with %d", runtime.NumGoroutine(), before)
+		}
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -4,34 +4,163 @@
 package interpreter
 
 import (
+	"strings"
+
+	"github.com/AndrewDonelson/trumplang/internal/errors"
 	"github.com/AndrewDonelson/trumplang/internal/parser"
 )
 
 // Evaluate an identifier
 func (e *Evaluator) evalIdentifier(node *parser.Identifier) Object {
+	// Check for variables in the environment, which may shadow built-ins
+	val, ok := e.env.Get(node.Value)
+	if ok {
+		return val
+	}
+
 	// Check for built-in functions
 	if builtin, ok := e.builtins[node.Value]; ok {
 		return builtin
 	}
 
-	// Check for variables in the environment
-	val, ok := e.env.Get(node.Value)
+	// Easter egg: Undefined variables are "covfefe"
+	if e.rand.Float64() < 0.1 {
+		return newCodedError(errors.UNDEFINED_IDENTIFIER, "Nobody knows what this '%s' covfefe means, but it's provocative!", node.Value)
+	}
+	return newCodedError(errors.UNDEFINED_IDENTIFIER, "identifier not found: %s", node.Value)
+}
+
+// Evaluate an assignment, applying the operator for compound forms like "+="
+func (e *Evaluator) evalAssignExpression(node *parser.AssignExpression) Object {
+	if target, ok := node.Target.(*parser.IndexExpression); ok {
+		return e.evalIndexAssignExpression(node, target)
+	}
+
+	name := node.Target.(*parser.Identifier).Value
+
+	current, ok := e.env.Get(name)
 	if !ok {
-		// Easter egg: Undefined variables are "covfefe"
-		if e.rand.Float64() < 0.1 {
-			return newError("Nobody knows what this '%s' covfefe means, but it's provocative!", node.Value)
+		return newCodedError(errors.UNDEFINED_IDENTIFIER, "identifier not found: %s", name)
+	}
+	if e.env.Constant(name) {
+		return newCodedError(errors.CONSTANT_ASSIGNMENT, "cannot assign to %s, it is a TREMENDOUS constant", name)
+	}
+
+	val := e.Eval(node.Value)
+	if IsError(val) {
+		return val
+	}
+
+	if node.Operator != "=" {
+		val = e.evalInfixExpression(strings.TrimSuffix(node.Operator, "="), current, val)
+		if IsError(val) {
+			return val
+		}
+	}
+
+	if err := checkVariableAnnotation(name, e.env.Annotation(name), val); err != nil {
+		return err
+	}
+
+	e.env.Assign(name, val)
+	return val
+}
+
+// Evaluate an assignment to an element, e.g. deals[0] = 45. Arrays and
+// hashes are updated in place.
+func (e *Evaluator) evalIndexAssignExpression(node *parser.AssignExpression, target *parser.IndexExpression) Object {
+	left := e.Eval(target.Left)
+	if IsError(left) {
+		return left
+	}
+
+	index := e.Eval(target.Index)
+	if IsError(index) {
+		return index
+	}
+
+	val := e.Eval(node.Value)
+	if IsError(val) {
+		return val
+	}
+
+	switch container := left.(type) {
+	case *Array:
+		idx, ok := index.(*Integer)
+		if !ok {
+			return newError("array index must be INTEGER, got %s", index.Type())
+		}
+
+		// The current value is read and the new one written under the
+		// array's lock, but the operator runs outside it
+		if node.Operator != "=" {
+			current, ok := container.Get(idx.Value)
+			if !ok {
+				return newCodedError(errors.INDEX_OUT_OF_RANGE, "array index %d out of range for length %d", idx.Value, container.Len())
+			}
+			val = e.evalInfixExpression(strings.TrimSuffix(node.Operator, "="), current, val)
+			if IsError(val) {
+				return val
+			}
+		}
+
+		if !container.Set(idx.Value, val) {
+			return newCodedError(errors.INDEX_OUT_OF_RANGE, "array index %d out of range for length %d", idx.Value, container.Len())
+		}
+	case *Hash:
+		key, ok := index.(Hashable)
+		if !ok {
+			return newError("unusable as hash key: %s", index.Type())
 		}
-		return newError("identifier not found: " + node.Value)
+
+		if node.Operator != "=" {
+			current, found := container.Get(key)
+			if !found {
+				return newCodedError(errors.UNDEFINED_IDENTIFIER, "key not found: %s", index.Inspect())
+			}
+			val = e.evalInfixExpression(strings.TrimSuffix(node.Operator, "="), current, val)
+			if IsError(val) {
+				return val
+			}
+		}
+
+		container.Set(index, val)
+	case *String:
+		return newCodedError(errors.TYPE_MISMATCH, "cannot assign to a STRING index, strings are immutable")
+	default:
+		return newError("index assignment not supported: %s", left.Type())
 	}
 
 	return val
 }
 
+// Resolve a possibly negative index against a length, reporting whether it
+// is in range. Negative indices count back from the end.
+func normalizeIndex(index int64, length int) (int, bool) {
+	if index < 0 {
+		index += int64(length)
+	}
+	if index < 0 || index >= int64(length) {
+		return 0, false
+	}
+	return int(index), true
+}
+
 // Evaluate an index expression
 func (e *Evaluator) evalIndexExpression(left, index Object) Object {
 	switch {
 	case left.Type() == ARRAY_OBJ:
 		return e.evalArrayIndexExpression(left, index)
+	case left.Type() == STRING_OBJ:
+		return e.evalStringIndexExpression(left, index)
+	case left.Type() == HASH_OBJ:
+		return e.evalHashIndexExpression(left, index)
+	case left.Type() == RANGE_OBJ:
+		return e.evalRangeIndexExpression(left, index)
+	case left.Type() == MODULE_OBJ:
+		return e.evalModuleIndexExpression(left, index)
+	case left.Type() == FAKE_NEWS_OBJ:
+		return e.evalFakeNewsIndexExpression(left, index)
 	default:
 		return newError("index operator not supported: %s", left.Type())
 	}
@@ -45,11 +174,174 @@ func (e *Evaluator) evalArrayIndexExpression(array, index Object) Object {
 		return newError("array index must be INTEGER, got %s", index.Type())
 	}
 
-	if idx.Value < 0 || idx.Value >= int64(len(arrayObject.Elements)) {
+	element, ok := arrayObject.Get(idx.Value)
+	if !ok {
+		if e.strict {
+			return newCodedError(errors.INDEX_OUT_OF_RANGE, "array index %d out of range for length %d", idx.Value, arrayObject.Len())
+		}
 		return e.NULL
 	}
 
-	return arrayObject.Elements[idx.Value]
+	return element
+}
+
+// Evaluate a string index expression, counting in characters
+func (e *Evaluator) evalStringIndexExpression(str, index Object) Object {
+	runes := []rune(str.(*String).Value)
+	idx, ok := index.(*Integer)
+	if !ok {
+		return newError("string index must be INTEGER, got %s", index.Type())
+	}
+
+	i, ok := normalizeIndex(idx.Value, len(runes))
+	if !ok {
+		if e.strict {
+			return newCodedError(errors.INDEX_OUT_OF_RANGE, "string index %d out of range for length %d", idx.Value, len(runes))
+		}
+		return e.NULL
+	}
+
+	return &String{Value: string(runes[i])}
+}
+
+// Evaluate a slice expression. Negative bounds count back from the end,
+// bounds are clamped to the length of the value and an omitted bound means
+// its start or end. Slicing an array copies its elements.
+func (e *Evaluator) evalSliceExpression(node *parser.SliceExpression) Object {
+	left := e.Eval(node.Left)
+	if IsError(left) {
+		return left
+	}
+
+	switch left := left.(type) {
+	case *String:
+		runes := []rune(left.Value)
+		start, end, err := e.evalSliceBounds(node, len(runes))
+		if err != nil {
+			return err
+		}
+		return &String{Value: string(runes[start:end])}
+	case *Array:
+		elements := left.Snapshot()
+		start, end, err := e.evalSliceBounds(node, len(elements))
+		if err != nil {
+			return err
+		}
+		return &Array{Elements: elements[start:end]}
+	default:
+		return newError("slice operator not supported: %s", left.Type())
+	}
+}
+
+// Evaluate both bounds of a slice expression over a value of the given length
+func (e *Evaluator) evalSliceBounds(node *parser.SliceExpression, length int) (int, int, Object) {
+	start, err := e.evalSliceBound(node.Start, 0, length)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	end, err := e.evalSliceBound(node.End, length, length)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if start > end {
+		start = end
+	}
+
+	return start, end, nil
+}
+
+// Evaluate one bound of a slice expression, using def when it is omitted
+func (e *Evaluator) evalSliceBound(node parser.Expression, def, length int) (int, Object) {
+	if node == nil {
+		return def, nil
+	}
+
+	val := e.Eval(node)
+	if IsError(val) {
+		return 0, val
+	}
+
+	bound, ok := val.(*Integer)
+	if !ok {
+		return 0, newError("slice bound must be INTEGER, got %s", val.Type())
+	}
+
+	value := bound.Value
+	if value < 0 {
+		value += int64(length)
+	}
+
+	switch {
+	case value < 0:
+		return 0, nil
+	case value > int64(length):
+		return length, nil
+	default:
+		return int(value), nil
+	}
+}
+
+// Evaluate a hash literal
+func (e *Evaluator) evalHashLiteral(node *parser.HashLiteral) Object {
+	hash := NewHash()
+
+	for _, pair := range node.Pairs {
+		key := e.Eval(pair.Key)
+		if IsError(key) {
+			return key
+		}
+
+		if _, ok := key.(Hashable); !ok {
+			return newError("unusable as hash key: %s", key.Type())
+		}
+
+		value := e.Eval(pair.Value)
+		if IsError(value) {
+			return value
+		}
+
+		hash.Set(key, value)
+	}
+
+	return hash
+}
+
+// Evaluate a hash index expression
+func (e *Evaluator) evalHashIndexExpression(hash, index Object) Object {
+	hashObject := hash.(*Hash)
+
+	key, ok := index.(Hashable)
+	if !ok {
+		return newError("unusable as hash key: %s", index.Type())
+	}
+
+	value, ok := hashObject.Get(key)
+	if !ok {
+		return e.NULL
+	}
+
+	return value
+}
+
+// Evaluate a field lookup on a caught error, e.g. err["message"]
+func (e *Evaluator) evalFakeNewsIndexExpression(fakeNews, index Object) Object {
+	fakeNewsObject := fakeNews.(*FakeNews)
+
+	field, ok := index.(*String)
+	if !ok {
+		return newError("FAKE_NEWS field must be STRING, got %s", index.Type())
+	}
+
+	switch field.Value {
+	case "message":
+		return &String{Value: fakeNewsObject.Message}
+	case "code":
+		return &String{Value: fakeNewsObject.Code}
+	default:
+		return e.NULL
+	}
 }
 
 // Evaluate expressions
@@ -71,12 +363,25 @@ func (e *Evaluator) evalExpressions(exps []parser.Expression) []Object {
 func (e *Evaluator) applyFunction(fn Object, args []Object) Object {
 	switch fn := fn.(type) {
 	case *Function:
+		if err := checkArgumentAnnotations(fn, args); err != nil {
+			return err
+		}
+		if fn.Generator {
+			return e.newGenerator(fn, args)
+		}
+
 		extendedEnv := e.extendFunctionEnv(fn, args)
-		oldEnv := e.env
-		e.env = extendedEnv
+		oldEnv, oldBigly := e.env, e.bigly
+		e.env, e.bigly = extendedEnv, fn.Bigly
 
 		evaluated := e.Eval(fn.Body)
-		e.env = oldEnv
+		e.env, e.bigly = oldEnv, oldBigly
+
+		// Only values given to RETURN are checked, since a function that
+		// runs off the end of its body returns whatever it last evaluated
+		if returned, ok := evaluated.(*ReturnValue); ok && !matchesAnnotation(fn.ReturnType, returned.Value) {
+			return newCodedError(errors.TYPE_MISMATCH, "cannot return %s from a function returning %s", returned.Value.Type(), fn.ReturnType)
+		}
 
 		return unwrapReturnValue(evaluated)
 	case *Builtin:
@@ -91,10 +396,15 @@ func (e *Evaluator) extendFunctionEnv(fn *Function, args []Object) *Environment
 	env := NewEnclosedEnvironment(fn.Env)
 
 	for paramIdx, param := range fn.Parameters {
+		annotation := ""
+		if paramIdx < len(fn.ParameterTypes) {
+			annotation = fn.ParameterTypes[paramIdx]
+		}
+
 		if paramIdx < len(args) {
-			env.Set(param.Value, args[paramIdx])
+			env.SetParameter(param.Value, args[paramIdx], annotation)
 		} else {
-			env.Set(param.Value, e.NULL)
+			env.SetParameter(param.Value, e.NULL, annotation)
 		}
 	}
 
@@ -0,0 +1,17 @@
+// file: internal/interpreter/lambda_test.go
+// description: Tests for arrow lambdas
+
+package interpreter
+
+import "testing"
+
+func TestLambdas(t *testing.T) {
+	runEvalTests(t, []evalTest{
+		{"call", `YUGE double = (x) => x * 2; double(21);`, "42"},
+		{"closure", `YUGE a = 2; YUGE f = (x, y) => { YUGE z = x + y; RETURN z * a; }; f(1, 2);`, "6"},
+		{"expression body", `(x) => x * 2;`, "(x) => (x * 2)"},
+		{"block body", `YUGE a = 2; (x, y) => { YUGE z = x + y; RETURN z * a; };`, "(x, y) => { YUGE z = (x + y); RETURN (z * a); }"},
+		{"expression statements", `(x) => { TWEET x; x + 1; };`, "(x) => { TWEET x; (x + 1); }"},
+		{"empty body", `() => {};`, "() => { }"},
+	})
+}
@@ -0,0 +1,181 @@
+// file: internal/interpreter/match.go
+// description: NEGOTIATE match expression evaluation for the TRUMP language
+
+package interpreter
+
+import (
+	"github.com/AndrewDonelson/trumplang/internal/parser"
+)
+
+// Evaluate a NEGOTIATE expression. Arms are tried in order and the first
+// one with a matching pattern and a passing guard is evaluated in a new
+// scope holding its bindings. Nothing matching gives COVFEFE.
+func (e *Evaluator) evalMatchExpression(node *parser.MatchExpression) Object {
+	subject := e.Eval(node.Subject)
+	if IsError(subject) {
+		return subject
+	}
+
+	for _, arm := range node.Arms {
+		for _, pattern := range arm.Patterns {
+			bindings := map[string]Object{}
+
+			matched, err := e.matchPattern(pattern, subject, bindings)
+			if err != nil {
+				return err
+			}
+			if !matched {
+				continue
+			}
+
+			env := NewEnclosedEnvironment(e.env)
+			for name, val := range bindings {
+				env.Set(name, val)
+			}
+
+			oldEnv := e.env
+			e.env = env
+			result, chosen := e.evalMatchArm(arm)
+			e.env = oldEnv
+
+			if chosen {
+				return result
+			}
+		}
+	}
+
+	return e.NULL
+}
+
+// Evaluate an arm whose pattern matched, reporting false if its guard fails
+func (e *Evaluator) evalMatchArm(arm *parser.MatchArm) (Object, bool) {
+	if arm.Guard != nil {
+		guard := e.Eval(arm.Guard)
+		if IsError(guard) {
+			return guard, true
+		}
+		if !IsTruthy(guard) {
+			return nil, false
+		}
+	}
+
+	if arm.Body != nil {
+		return e.Eval(arm.Body), true
+	}
+	return e.Eval(arm.Value), true
+}
+
+// Check a value against a pattern, collecting the names it binds
+func (e *Evaluator) matchPattern(pattern parser.Pattern, val Object, bindings map[string]Object) (bool, Object) {
+	switch pattern := pattern.(type) {
+	case *parser.WildcardPattern:
+		return true, nil
+
+	case *parser.BindingPattern:
+		bindings[pattern.Name.Value] = val
+		return true, nil
+
+	case *parser.TypePattern:
+		if pattern.Name == FUNCTION_OBJ && val.Type() == BUILTIN_OBJ {
+			return true, nil
+		}
+		return val.Type() == pattern.Name, nil
+
+	case *parser.LiteralPattern:
+		expected := e.Eval(pattern.Value)
+		if IsError(expected) {
+			return false, expected
+		}
+		return objectsEqual(val, expected), nil
+
+	case *parser.RangePattern:
+		low := e.Eval(pattern.Low)
+		if IsError(low) {
+			return false, low
+		}
+		high := e.Eval(pattern.High)
+		if IsError(high) {
+			return false, high
+		}
+
+		fromLow, ok := compareNumbers(val, low)
+		if !ok {
+			return false, nil
+		}
+		toHigh, ok := compareNumbers(val, high)
+		if !ok {
+			return false, nil
+		}
+
+		if pattern.Exclusive {
+			return fromLow >= 0 && toHigh < 0, nil
+		}
+		return fromLow >= 0 && toHigh <= 0, nil
+
+	case *parser.ArrayPattern:
+		array, ok := val.(*Array)
+		if !ok {
+			return false, nil
+		}
+
+		// Without a rest binding the lengths must agree exactly
+		elements := array.Snapshot()
+		count := len(pattern.Elements)
+		if len(elements) < count || (pattern.Rest == nil && len(elements) != count) {
+			return false, nil
+		}
+
+		for i, element := range pattern.Elements {
+			matched, err := e.matchPattern(element, elements[i], bindings)
+			if err != nil || !matched {
+				return false, err
+			}
+		}
+
+		if pattern.Rest != nil {
+			bindings[pattern.Rest.Value] = &Array{Elements: elements[count:]}
+		}
+
+		return true, nil
+
+	default:
+		return false, newError("unknown pattern: %s", pattern.String())
+	}
+}
+
+// Check two values for equality the way a NEGOTIATE literal does. Numbers
+// compare by value whatever their type; arrays compare element by element.
+func objectsEqual(left, right Object) bool {
+	if cmp, ok := compareNumbers(left, right); ok {
+		return cmp == 0
+	}
+
+	switch left := left.(type) {
+	case *String:
+		r, ok := right.(*String)
+		return ok && left.Value == r.Value
+	case *Boolean:
+		r, ok := right.(*Boolean)
+		return ok && left.Value == r.Value
+	case *Null:
+		_, ok := right.(*Null)
+		return ok
+	case *Array:
+		r, ok := right.(*Array)
+		if !ok {
+			return false
+		}
+		leftElements, rightElements := left.Snapshot(), r.Snapshot()
+		if len(leftElements) != len(rightElements) {
+			return false
+		}
+		for i := range leftElements {
+			if !objectsEqual(leftElements[i], rightElements[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return left == right
+	}
+}
@@ -0,0 +1,180 @@
+// file: internal/interpreter/modules.go
+// description: Module loading and import evaluation for the TRUMP language
+
+package interpreter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AndrewDonelson/trumplang/internal/errors"
+	"github.com/AndrewDonelson/trumplang/internal/lexer"
+	"github.com/AndrewDonelson/trumplang/internal/parser"
+)
+
+// Module represents a loaded file. Only its BORDER declarations are visible
+// to importers.
+type Module struct {
+	Path    string
+	Exports map[string]Object
+	Names   []string // Export names in declaration order
+}
+
+func (m *Module) Type() string { return MODULE_OBJ }
+func (m *Module) Inspect() string {
+	return "MODULE " + m.Path + " {" + strings.Join(m.Names, ", ") + "}"
+}
+
+// SetFile records the file the program being evaluated was read from, so
+// imports can be resolved relative to it
+func (e *Evaluator) SetFile(path string) {
+	e.file = path
+	if abs, err := filepath.Abs(path); err == nil {
+		e.importStack = []string{abs}
+	}
+}
+
+// Evaluate an import statement, binding the module's exports (or the module
+// itself when an alias is given) in the current scope
+func (e *Evaluator) evalImportStatement(is *parser.ImportStatement) Object {
+	loaded := e.loadModule(is.Path)
+	if IsError(loaded) {
+		return loaded
+	}
+	module := loaded.(*Module)
+
+	if is.Alias != nil {
+		e.env.Set(is.Alias.Value, module)
+		return module
+	}
+
+	for _, name := range module.Names {
+		e.env.Set(name, module.Exports[name])
+	}
+
+	return module
+}
+
+// Load, parse and evaluate a module, or return it from the cache if it has
+// already been loaded
+func (e *Evaluator) loadModule(importPath string) Object {
+	path := e.resolveImportPath(importPath)
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return newCodedError(errors.FILE_NOT_FOUND, "cannot resolve import %q", importPath)
+	}
+
+	e.moduleLock.Lock()
+	module, ok := e.modules[abs]
+	e.moduleLock.Unlock()
+	if ok {
+		return module
+	}
+
+	for i, loading := range e.importStack {
+		if loading == abs {
+			cycle := []string{}
+			for _, p := range e.importStack[i:] {
+				cycle = append(cycle, displayPath(p))
+			}
+			cycle = append(cycle, displayPath(abs))
+			return newCodedError(errors.IMPORT_ERROR, "import cycle: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	input, err := os.ReadFile(path)
+	if err != nil {
+		return newCodedError(errors.FILE_NOT_FOUND, "cannot read module %s", path)
+	}
+
+	l := lexer.NewWithFile(string(input), path)
+	p := parser.New(l)
+	program := p.Parse()
+
+	if len(p.Errors()) > 0 {
+		return newCodedError(errors.SYNTAX_ERROR, "parsing errors in module %s:\n    %s", path, strings.Join(p.Errors(), "\n    "))
+	}
+
+	// Evaluate the module in its own environment
+	outerEnv, outerFile := e.env, e.file
+	e.env, e.file = NewEnvironment(), path
+	e.importStack = append(e.importStack, abs)
+
+	result := e.Eval(program)
+	moduleEnv := e.env
+
+	e.importStack = e.importStack[:len(e.importStack)-1]
+	e.env, e.file = outerEnv, outerFile
+
+	if err, ok := result.(*Error); ok {
+		return newCodedError(err.Code, "%s", errors.InFile(path, err.Message))
+	}
+
+	module = &Module{Path: path, Exports: make(map[string]Object)}
+	for _, statement := range program.Statements {
+		export, ok := statement.(*parser.ExportStatement)
+		if !ok {
+			continue
+		}
+		if val, ok := moduleEnv.Get(export.Name.Value); ok {
+			module.Exports[export.Name.Value] = val
+			module.Names = append(module.Names, export.Name.Value)
+		}
+	}
+
+	// A module imported by two tasks at once is loaded by both, and the
+	// first one loaded is kept
+	e.moduleLock.Lock()
+	defer e.moduleLock.Unlock()
+	if loaded, ok := e.modules[abs]; ok {
+		return loaded
+	}
+	e.modules[abs] = module
+	return module
+}
+
+// Resolve an import path relative to the file currently being evaluated,
+// adding the .trump extension if it was left off
+func (e *Evaluator) resolveImportPath(importPath string) string {
+	if !strings.HasSuffix(importPath, ".trump") {
+		importPath += ".trump"
+	}
+	if filepath.IsAbs(importPath) {
+		return importPath
+	}
+
+	dir := "."
+	if e.file != "" {
+		dir = filepath.Dir(e.file)
+	}
+	return filepath.Join(dir, importPath)
+}
+
+// Evaluate an export lookup on a module, e.g. utils["greet"]
+func (e *Evaluator) evalModuleIndexExpression(module, index Object) Object {
+	moduleObject := module.(*Module)
+
+	name, ok := index.(*String)
+	if !ok {
+		return newError("module export name must be STRING, got %s", index.Type())
+	}
+
+	val, ok := moduleObject.Exports[name.Value]
+	if !ok {
+		return newCodedError(errors.UNDEFINED_IDENTIFIER, "module %s has no export %s", moduleObject.Path, name.Value)
+	}
+
+	return val
+}
+
+// Shorten an absolute path relative to the working directory for messages
+func displayPath(abs string) string {
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, abs); err == nil {
+			return rel
+		}
+	}
+	return abs
+}
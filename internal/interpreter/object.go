@@ -3,18 +3,36 @@
 
 package interpreter
 
+import (
+	"sync"
+
+	"github.com/AndrewDonelson/trumplang/internal/parser"
+)
+
 // Object types
 const (
-	INTEGER_OBJ  = "INTEGER"
-	FLOAT_OBJ    = "FLOAT"
-	BOOLEAN_OBJ  = "BOOLEAN"
-	STRING_OBJ   = "STRING"
-	NULL_OBJ     = "NULL"
-	RETURN_OBJ   = "RETURN"
-	ERROR_OBJ    = "ERROR"
-	FUNCTION_OBJ = "FUNCTION"
-	BUILTIN_OBJ  = "BUILTIN"
-	ARRAY_OBJ    = "ARRAY"
+	INTEGER_OBJ   = "INTEGER"
+	BIG_INT_OBJ   = "BIG_INTEGER"
+	DECIMAL_OBJ   = "BILLIONS"
+	FLOAT_OBJ     = "FLOAT"
+	BOOLEAN_OBJ   = "BOOLEAN"
+	STRING_OBJ    = "STRING"
+	NULL_OBJ      = "NULL"
+	RETURN_OBJ    = "RETURN"
+	ERROR_OBJ     = "ERROR"
+	BREAK_OBJ     = "BREAK"
+	CONTINUE_OBJ  = "CONTINUE"
+	FUNCTION_OBJ  = "FUNCTION"
+	BUILTIN_OBJ   = "BUILTIN"
+	ARRAY_OBJ     = "ARRAY"
+	HASH_OBJ      = "HASH"
+	RANGE_OBJ     = "RANGE"
+	GENERATOR_OBJ = "GENERATOR"
+
+	FAKE_NEWS_OBJ = "FAKE_NEWS"
+	MODULE_OBJ    = "MODULE"
+	TASK_OBJ      = "TASK"
+	CHANNEL_OBJ   = "CHANNEL"
 )
 
 // Object interface that all objects implement
@@ -23,16 +41,28 @@ type Object interface {
 	Inspect() string
 }
 
-// Environment stores variable bindings
+// Environment stores variable bindings. Closures share environments
+// between concurrent tasks, so every access holds its lock.
 type Environment struct {
-	store map[string]Object
+	mu    sync.RWMutex
+	store map[string]*binding
 	outer *Environment
 }
 
+// binding is a single name in an Environment. Constants, declared with
+// TREMENDOUS, cannot be assigned to after their declaration.
+type binding struct {
+	value    Object
+	constant bool
+	site     parser.Node // The declaration that made the binding, nil for parameters and imports
+
+	annotation string // The type the name was declared with, empty when dynamically typed
+}
+
 // NewEnvironment creates a new Environment
 func NewEnvironment() *Environment {
 	return &Environment{
-		store: make(map[string]Object),
+		store: make(map[string]*binding),
 		outer: nil,
 	}
 }
@@ -46,19 +76,115 @@ func NewEnclosedEnvironment(outer *Environment) *Environment {
 
 // Get retrieves a value from the environment
 func (e *Environment) Get(name string) (Object, bool) {
-	obj, ok := e.store[name]
-	if !ok && e.outer != nil {
-		obj, ok = e.outer.Get(name)
+	e.mu.RLock()
+	b, ok := e.store[name]
+	var val Object
+	if ok {
+		val = b.value
+	}
+	e.mu.RUnlock()
+
+	if !ok {
+		if e.outer != nil {
+			return e.outer.Get(name)
+		}
+		return nil, false
 	}
-	return obj, ok
+	return val, true
 }
 
-// Set sets a value in the environment
+// Set binds a mutable value in the environment, replacing any binding the
+// name already has in this scope
 func (e *Environment) Set(name string, val Object) Object {
-	e.store[name] = val
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.store[name] = &binding{value: val}
 	return val
 }
 
+// Declare binds a value made by a declaration in this scope. Running the
+// same declaration again, as a loop body does, rebinds the name. It reports
+// false if a different declaration already bound the name here and either
+// of them is a constant.
+func (e *Environment) Declare(name string, val Object, constant bool, site parser.Node) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if b, ok := e.store[name]; ok && b.site != site && (b.constant || constant) {
+		return false
+	}
+	annotation := ""
+	if let, ok := site.(*parser.LetStatement); ok {
+		annotation = let.Type
+	}
+	e.store[name] = &binding{value: val, constant: constant, site: site, annotation: annotation}
+	return true
+}
+
+// SetParameter binds a function parameter in this scope, along with the
+// type it was annotated with, if any
+func (e *Environment) SetParameter(name string, val Object, annotation string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.store[name] = &binding{value: val, annotation: annotation}
+}
+
+// Constant reports whether a name refers to a constant
+func (e *Environment) Constant(name string) bool {
+	e.mu.RLock()
+	b, ok := e.store[name]
+	e.mu.RUnlock()
+
+	if ok {
+		return b.constant
+	}
+	if e.outer != nil {
+		return e.outer.Constant(name)
+	}
+	return false
+}
+
+// Annotation returns the type a variable or parameter was declared with, or
+// "" if it was declared without one
+func (e *Environment) Annotation(name string) string {
+	e.mu.RLock()
+	b, ok := e.store[name]
+	e.mu.RUnlock()
+
+	if ok {
+		return b.annotation
+	}
+	if e.outer != nil {
+		return e.outer.Annotation(name)
+	}
+	return ""
+}
+
+// Assign updates an existing binding in whichever enclosing environment
+// defined it. It reports false if the name has not been declared or is a
+// constant.
+func (e *Environment) Assign(name string, val Object) (Object, bool) {
+	e.mu.Lock()
+	b, ok := e.store[name]
+	if ok && !b.constant {
+		b.value = val
+	}
+	e.mu.Unlock()
+
+	if ok {
+		if b.constant {
+			return nil, false
+		}
+		return val, true
+	}
+	if e.outer != nil {
+		return e.outer.Assign(name, val)
+	}
+	return nil, false
+}
+
 // Check if an object is an error
 func IsError(obj Object) bool {
 	if obj != nil {
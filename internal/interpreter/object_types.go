@@ -5,7 +5,10 @@ package interpreter
 
 import (
 	"fmt"
+	"hash/fnv"
+	"math/big"
 	"strings"
+	"sync"
 
 	"github.com/AndrewDonelson/trumplang/internal/parser"
 )
@@ -24,6 +27,24 @@ func (i *Integer) Inspect() string {
 	return fmt.Sprintf("%d", i.Value)
 }
 
+// BigInteger represents an integer too large for 64 bits, produced by
+// arithmetic in BIGLY files. Values that fit in 64 bits are always Integer.
+type BigInteger struct {
+	Value *big.Int
+}
+
+func (bi *BigInteger) Type() string    { return BIG_INT_OBJ }
+func (bi *BigInteger) Inspect() string { return bi.Value.String() }
+
+// Decimal represents an exact fixed-point number, Value * 10^-Scale
+type Decimal struct {
+	Value *big.Int
+	Scale int // Number of digits after the decimal point
+}
+
+func (d *Decimal) Type() string    { return DECIMAL_OBJ }
+func (d *Decimal) Inspect() string { return formatDecimal(d) }
+
 // Float represents a floating-point value
 type Float struct {
 	Value float64
@@ -67,24 +88,63 @@ type ReturnValue struct {
 func (rv *ReturnValue) Type() string    { return RETURN_OBJ }
 func (rv *ReturnValue) Inspect() string { return rv.Value.Inspect() }
 
-// Error represents an error value
+// Break signals a YOU'RE FIRED statement unwinding to its loop
+type Break struct {
+	Label string // Empty to target the innermost loop
+}
+
+func (b *Break) Type() string    { return BREAK_OBJ }
+func (b *Break) Inspect() string { return "YOU'RE FIRED" }
+
+// Continue signals a NEXT DEAL statement unwinding to its loop
+type Continue struct {
+	Label string // Empty to target the innermost loop
+}
+
+func (c *Continue) Type() string    { return CONTINUE_OBJ }
+func (c *Continue) Inspect() string { return "NEXT DEAL" }
+
+// Error represents an error value that unwinds evaluation until it is
+// caught by a DENY/BLAME statement
 type Error struct {
+	Code    string // Error code from the errors package, e.g. DIVISION_BY_ZERO
 	Message string
 }
 
 func (e *Error) Type() string    { return ERROR_OBJ }
 func (e *Error) Inspect() string { return "ERROR: " + e.Message }
 
+// FakeNews is a caught error bound by a BLAME clause. Unlike Error it is an
+// ordinary value, exposing err["message"] and err["code"]
+type FakeNews struct {
+	Code    string
+	Message string
+}
+
+func (fn *FakeNews) Type() string    { return FAKE_NEWS_OBJ }
+func (fn *FakeNews) Inspect() string { return "FAKE NEWS (" + fn.Code + "): " + fn.Message }
+
 // Function represents a function definition
 type Function struct {
+	Name       string // Empty for anonymous functions
 	Parameters []*parser.Identifier
 	Body       *parser.BlockStatement
 	Env        *Environment
 	Rating     string // Optional rating (e.g., "10/10")
+	Bigly      bool   // Whether it was defined in a BIGLY file
+	Lambda     bool   // Whether it was written with "=>"
+	Generator  bool   // Whether calling it starts a generator
+
+	ParameterTypes []string // Optional type annotation of each parameter
+	ReturnType     string   // Optional return type annotation, the type yielded for generators
 }
 
 func (f *Function) Type() string { return FUNCTION_OBJ }
 func (f *Function) Inspect() string {
+	if f.Lambda {
+		return parser.LambdaString(f.Parameters, nil, f.Body)
+	}
+
 	var out strings.Builder
 
 	params := []string{}
@@ -93,6 +153,9 @@ func (f *Function) Inspect() string {
 	}
 
 	out.WriteString("YUGE FUNCTION")
+	if f.Name != "" {
+		out.WriteString(" " + f.Name)
+	}
 	out.WriteString("(")
 	out.WriteString(strings.Join(params, ", "))
 	out.WriteString(") ")
@@ -116,17 +179,64 @@ type Builtin struct {
 func (b *Builtin) Type() string    { return BUILTIN_OBJ }
 func (b *Builtin) Inspect() string { return "BUILT-IN FUNCTION" }
 
-// Array represents an array value
+// Array represents an array value. Closures can share an array between
+// tasks, so once it has been built it is read and written through its
+// methods, which hold its lock.
 type Array struct {
+	mu       sync.RWMutex
 	Elements []Object
 }
 
+// Len returns the number of elements
+func (a *Array) Len() int {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return len(a.Elements)
+}
+
+// Get returns the element at an index, counting back from the end for
+// negative indexes. It reports false if the index is out of range.
+func (a *Array) Get(index int64) (Object, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	i, ok := normalizeIndex(index, len(a.Elements))
+	if !ok {
+		return nil, false
+	}
+	return a.Elements[i], true
+}
+
+// Set replaces the element at an index, counting back from the end for
+// negative indexes. It reports false if the index is out of range.
+func (a *Array) Set(index int64, val Object) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	i, ok := normalizeIndex(index, len(a.Elements))
+	if !ok {
+		return false
+	}
+	a.Elements[i] = val
+	return true
+}
+
+// Snapshot returns a copy of the elements as they are now
+func (a *Array) Snapshot() []Object {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	elements := make([]Object, len(a.Elements))
+	copy(elements, a.Elements)
+	return elements
+}
+
 func (a *Array) Type() string { return ARRAY_OBJ }
 func (a *Array) Inspect() string {
 	var out strings.Builder
 
 	elements := []string{}
-	for _, e := range a.Elements {
+	for _, e := range a.Snapshot() {
 		elements = append(elements, e.Inspect())
 	}
 
@@ -136,3 +246,169 @@ func (a *Array) Inspect() string {
 
 	return out.String()
 }
+
+// Range represents a lazy sequence of integers. Its elements are worked out
+// when needed, so a range takes the same memory however long it is.
+type Range struct {
+	Start     int64
+	End       int64
+	Step      int64
+	Exclusive bool // Whether End is left out
+}
+
+func (r *Range) Type() string { return RANGE_OBJ }
+func (r *Range) Inspect() string {
+	operator := ".."
+	if r.Exclusive {
+		operator = "..<"
+	}
+
+	out := fmt.Sprintf("%d%s%d", r.Start, operator, r.End)
+	if r.Step != defaultStep(r.Start, r.End) {
+		out += fmt.Sprintf(" BY %d", r.Step)
+	}
+
+	return out
+}
+
+// HashKey identifies a hashable value used as a key in a Hash
+type HashKey struct {
+	Type  string
+	Value uint64
+}
+
+// Hashable is implemented by objects that can be used as hash keys
+type Hashable interface {
+	HashKey() HashKey
+}
+
+func (i *Integer) HashKey() HashKey {
+	return HashKey{Type: i.Type(), Value: uint64(i.Value)}
+}
+
+func (bi *BigInteger) HashKey() HashKey {
+	h := fnv.New64a()
+	h.Write(bi.Value.Bytes())
+	return HashKey{Type: bi.Type(), Value: h.Sum64() ^ uint64(bi.Value.Sign())}
+}
+
+func (b *Boolean) HashKey() HashKey {
+	var value uint64
+	if b.Value {
+		value = 1
+	}
+	return HashKey{Type: b.Type(), Value: value}
+}
+
+func (s *String) HashKey() HashKey {
+	h := fnv.New64a()
+	h.Write([]byte(s.Value))
+	return HashKey{Type: s.Type(), Value: h.Sum64()}
+}
+
+// HashPair holds the original key object alongside its value
+type HashPair struct {
+	Key   Object
+	Value Object
+}
+
+// Hash represents a hash map value that remembers insertion order. Like
+// arrays, hashes can be shared between tasks, so their methods hold a lock.
+type Hash struct {
+	mu    sync.RWMutex
+	Pairs map[HashKey]HashPair
+	Order []HashKey
+}
+
+// NewHash creates an empty Hash
+func NewHash() *Hash {
+	return &Hash{Pairs: make(map[HashKey]HashPair)}
+}
+
+// Get looks up the value stored under key
+func (h *Hash) Get(key Hashable) (Object, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	pair, ok := h.Pairs[key.HashKey()]
+	if !ok {
+		return nil, false
+	}
+	return pair.Value, true
+}
+
+// Set stores value under key, keeping the original position of existing keys
+func (h *Hash) Set(key Object, value Object) {
+	hashKey := key.(Hashable).HashKey()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.Pairs[hashKey]; !ok {
+		h.Order = append(h.Order, hashKey)
+	}
+	h.Pairs[hashKey] = HashPair{Key: key, Value: value}
+}
+
+// Delete removes key from the hash
+func (h *Hash) Delete(key Hashable) {
+	hashKey := key.HashKey()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.Pairs[hashKey]; !ok {
+		return
+	}
+	delete(h.Pairs, hashKey)
+	for i, k := range h.Order {
+		if k == hashKey {
+			h.Order = append(h.Order[:i:i], h.Order[i+1:]...)
+			break
+		}
+	}
+}
+
+// Len returns the number of keys
+func (h *Hash) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.Order)
+}
+
+// Entries returns the key and value pairs in insertion order, as they are now
+func (h *Hash) Entries() []HashPair {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	pairs := make([]HashPair, 0, len(h.Order))
+	for _, k := range h.Order {
+		pairs = append(pairs, h.Pairs[k])
+	}
+	return pairs
+}
+
+// Copy returns a shallow copy of the hash
+func (h *Hash) Copy() *Hash {
+	out := NewHash()
+	for _, pair := range h.Entries() {
+		out.Set(pair.Key, pair.Value)
+	}
+	return out
+}
+
+func (h *Hash) Type() string { return HASH_OBJ }
+func (h *Hash) Inspect() string {
+	var out strings.Builder
+
+	pairs := []string{}
+	for _, pair := range h.Entries() {
+		pairs = append(pairs, pair.Key.Inspect()+": "+pair.Value.Inspect())
+	}
+
+	out.WriteString("{")
+	out.WriteString(strings.Join(pairs, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
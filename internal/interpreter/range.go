@@ -0,0 +1,195 @@
+// file: internal/interpreter/range.go
+// description: Lazy integer ranges for the TRUMP programming language
+
+package interpreter
+
+import (
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/AndrewDonelson/trumplang/internal/errors"
+	"github.com/AndrewDonelson/trumplang/internal/parser"
+)
+
+// Evaluate a range expression. Without a step a range counts up by one, or
+// down by one if it ends below its start.
+func (e *Evaluator) evalRangeExpression(node *parser.RangeExpression) Object {
+	start, err := e.evalRangeBound(node.Start)
+	if err != nil {
+		return err
+	}
+	end, err := e.evalRangeBound(node.End)
+	if err != nil {
+		return err
+	}
+
+	step := defaultStep(start, end)
+	if node.Step != nil {
+		if step, err = e.evalRangeBound(node.Step); err != nil {
+			return err
+		}
+		if step == 0 {
+			return newError("range step cannot be zero")
+		}
+	}
+
+	return &Range{Start: start, End: end, Step: step, Exclusive: node.Exclusive}
+}
+
+// Evaluate the start, end or step of a range, which must be an integer
+func (e *Evaluator) evalRangeBound(node parser.Expression) (int64, Object) {
+	val := e.Eval(node)
+	if IsError(val) {
+		return 0, val
+	}
+
+	integer, ok := val.(*Integer)
+	if !ok {
+		return 0, newCodedError(errors.TYPE_MISMATCH, "range bounds must be INTEGER, got %s", val.Type())
+	}
+
+	return integer.Value, nil
+}
+
+// The step of a range written without one
+func defaultStep(start, end int64) int64 {
+	if end < start {
+		return -1
+	}
+	return 1
+}
+
+// Last returns the position of the last integer in the range, reporting
+// false if the range is empty. The distances are worked out in unsigned
+// arithmetic so that ranges spanning most of the INTEGER values do not
+// overflow. Loops count up to the last position rather than the length,
+// since a range over every INTEGER value has one more than fits in a uint64.
+func (r *Range) Last() (uint64, bool) {
+	if r.Exclusive && r.Start == r.End {
+		return 0, false
+	}
+
+	var span, step uint64
+	if r.Step > 0 {
+		if r.End < r.Start {
+			return 0, false
+		}
+		span, step = uint64(r.End)-uint64(r.Start), uint64(r.Step)
+	} else {
+		if r.End > r.Start {
+			return 0, false
+		}
+		span, step = uint64(r.Start)-uint64(r.End), -uint64(r.Step)
+	}
+
+	if r.Exclusive {
+		span--
+	}
+
+	return span / step, true
+}
+
+// Len returns the number of integers in the range
+func (r *Range) Len() *big.Int {
+	last, ok := r.Last()
+	if !ok {
+		return new(big.Int)
+	}
+	length := new(big.Int).SetUint64(last)
+	return length.Add(length, big.NewInt(1))
+}
+
+// At returns the integer at a position in the range, which must be no
+// later than its last
+func (r *Range) At(i uint64) int64 {
+	return r.Start + int64(i)*r.Step
+}
+
+// Contains reports whether an integer is one of the range's elements
+func (r *Range) Contains(n int64) bool {
+	var offset, step uint64
+	if r.Step > 0 {
+		if n < r.Start {
+			return false
+		}
+		offset, step = uint64(n)-uint64(r.Start), uint64(r.Step)
+	} else {
+		if n > r.Start {
+			return false
+		}
+		offset, step = uint64(r.Start)-uint64(n), -uint64(r.Step)
+	}
+
+	last, ok := r.Last()
+	return ok && offset%step == 0 && offset/step <= last
+}
+
+// Make the INTEGER, or BIG_INTEGER past the largest INTEGER, for a position
+// in a range
+func rangePosition(i uint64) Object {
+	if i > math.MaxInt64 {
+		return &BigInteger{Value: new(big.Int).SetUint64(i)}
+	}
+	return &Integer{Value: int64(i)}
+}
+
+// Evaluate a range index expression. Negative indexes count back from the
+// end, as they do for arrays.
+func (e *Evaluator) evalRangeIndexExpression(rng, index Object) Object {
+	rangeObject := rng.(*Range)
+	idx, ok := index.(*Integer)
+	if !ok {
+		return newError("range index must be INTEGER, got %s", index.Type())
+	}
+
+	// Count back from the end for negative indexes, where -1 is the last
+	last, found := rangeObject.Last()
+	i := uint64(idx.Value)
+	if idx.Value < 0 {
+		back := uint64(-idx.Value) - 1
+		found = found && back <= last
+		i = last - back
+	}
+
+	if !found || i > last {
+		if e.strict {
+			return newCodedError(errors.INDEX_OUT_OF_RANGE, "range index %d out of range for length %s", idx.Value, rangeObject.Len())
+		}
+		return e.NULL
+	}
+
+	return &Integer{Value: rangeObject.At(i)}
+}
+
+// Evaluate a membership check, e.g. "5 IN 0..10". Arrays are searched for
+// an equal element, strings for a substring and hashes for a key.
+func (e *Evaluator) evalMembership(item, container Object) Object {
+	switch container := container.(type) {
+	case *Range:
+		n, ok := item.(*Integer)
+		return e.nativeBoolToBooleanObject(ok && container.Contains(n.Value))
+	case *Array:
+		for _, el := range container.Snapshot() {
+			if objectsEqual(item, el) {
+				return e.TRUE
+			}
+		}
+		return e.FALSE
+	case *String:
+		str, ok := item.(*String)
+		if !ok {
+			return newCodedError(errors.TYPE_MISMATCH, "type mismatch: %s IN STRING", item.Type())
+		}
+		return e.nativeBoolToBooleanObject(strings.Contains(container.Value, str.Value))
+	case *Hash:
+		key, ok := item.(Hashable)
+		if !ok {
+			return newError("unusable as hash key: %s", item.Type())
+		}
+		_, found := container.Get(key)
+		return e.nativeBoolToBooleanObject(found)
+	default:
+		return newCodedError(errors.TYPE_MISMATCH, "cannot look for a value IN %s", container.Type())
+	}
+}
@@ -0,0 +1,38 @@
+// file: internal/interpreter/range_test.go
+// description: Tests for lazy integer ranges
+
+package interpreter
+
+import "testing"
+
+// A range over every INTEGER value, which has more elements than fit in one
+const fullRange = `
+YUGE full = -9223372036854775807 - 1..9223372036854775807;
+`
+
+func TestRanges(t *testing.T) {
+	runEvalTests(t, []evalTest{
+		{"steps", `array(1..10 BY 3);`, "[1, 4, 7, 10]"},
+		{"counting down", `array(10..1 BY -4);`, "[10, 6, 2]"},
+		{"exclusive", `array(1..<4);`, "[1, 2, 3]"},
+		{"empty", `len(1..<1);`, "0"},
+		{"negative index", `[(1..10)[-1], (1..10)[-10], (1..10)[-11]];`, "[10, 1, COVFEFE]"},
+		{"full length", fullRange + `len(full);`, "18446744073709551616"},
+		{"full membership", fullRange + `[3 IN full, -9223372036854775807 - 1 IN full];`, "[WINNING, WINNING]"},
+		{"full index", fullRange + `[full[0], full[-1], full[9223372036854775807]];`, "[-9223372036854775808, 9223372036854775807, -1]"},
+		{
+			"full loop",
+			fullRange + `YUGE seen = [];
+			MAKE AMERICA GREAT AGAIN FOR (i, x IN full) { seen = push(seen, x); YOU'RE FIRED; }
+			seen;`,
+			"[-9223372036854775808]",
+		},
+		{
+			"loop ending at the largest INTEGER",
+			`YUGE seen = [];
+			MAKE AMERICA GREAT AGAIN FOR (i, x IN 9223372036854775806..9223372036854775807) { seen = push(seen, [i, x]); }
+			seen;`,
+			"[[0, 9223372036854775806], [1, 9223372036854775807]]",
+		},
+	})
+}
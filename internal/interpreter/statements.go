@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/AndrewDonelson/trumplang/internal/errors"
 	"github.com/AndrewDonelson/trumplang/internal/parser"
 )
 
@@ -17,6 +18,13 @@ import (
 func (e *Evaluator) evalProgram(program *parser.Program) Object {
 	var result Object = e.NULL
 
+	// Modules run in their own mode, so restore the importer's afterwards
+	oldBigly := e.bigly
+	e.bigly = program.Bigly
+	defer func() { e.bigly = oldBigly }()
+
+	e.hoistFunctionDeclarations(program.Statements)
+
 	for _, statement := range program.Statements {
 		result = e.Eval(statement)
 
@@ -35,12 +43,14 @@ func (e *Evaluator) evalProgram(program *parser.Program) Object {
 func (e *Evaluator) evalBlockStatement(block *parser.BlockStatement) Object {
 	var result Object = e.NULL
 
+	e.hoistFunctionDeclarations(block.Statements)
+
 	for _, statement := range block.Statements {
 		result = e.Eval(statement)
 
 		if result != nil {
 			rt := result.Type()
-			if rt == RETURN_OBJ || rt == ERROR_OBJ {
+			if rt == RETURN_OBJ || rt == ERROR_OBJ || rt == BREAK_OBJ || rt == CONTINUE_OBJ {
 				return result
 			}
 		}
@@ -49,9 +59,33 @@ func (e *Evaluator) evalBlockStatement(block *parser.BlockStatement) Object {
 	return result
 }
 
-// Evaluate an if statement
-func (e *Evaluator) evalIfStatement(is *parser.IfStatement) Object {
-	condition := e.Eval(is.Condition)
+// Bind every function declaration in a list of statements before any of
+// them run, so functions can be called before they are defined and can
+// refer to each other recursively
+func (e *Evaluator) hoistFunctionDeclarations(statements []parser.Statement) {
+	for _, statement := range statements {
+		if export, ok := statement.(*parser.ExportStatement); ok {
+			statement = export.Declaration
+		}
+		if decl, ok := statement.(*parser.FunctionDeclaration); ok {
+			e.evalFunctionDeclaration(decl)
+		}
+	}
+}
+
+// Evaluate a function declaration, binding the function in the current scope
+func (e *Evaluator) evalFunctionDeclaration(fd *parser.FunctionDeclaration) Object {
+	fn := e.Eval(fd.Function)
+	if !e.env.Declare(fd.Name.Value, fn, fd.Constant(), fd) {
+		return newCodedError(errors.REDECLARATION, "%s is already declared in this scope", fd.Name.Value)
+	}
+	return fn
+}
+
+// Evaluate an if statement or expression to the last value of the branch
+// taken
+func (e *Evaluator) evalIf(cond parser.Expression, consequence, alternative *parser.BlockStatement) Object {
+	condition := e.Eval(cond)
 	if IsError(condition) {
 		return condition
 	}
@@ -66,9 +100,9 @@ func (e *Evaluator) evalIfStatement(is *parser.IfStatement) Object {
 	}
 
 	if IsTruthy(condition) {
-		return e.Eval(is.Consequence)
-	} else if is.Alternative != nil {
-		return e.Eval(is.Alternative)
+		return e.Eval(consequence)
+	} else if alternative != nil {
+		return e.Eval(alternative)
 	} else {
 		return e.NULL
 	}
@@ -88,13 +122,10 @@ func (e *Evaluator) evalWhileStatement(ws *parser.WhileStatement) Object {
 	for IsTruthy(condition) && iterations < maxIterations {
 		iterations++
 
-		result = e.Eval(ws.Body)
-
-		if result != nil {
-			rt := result.Type()
-			if rt == RETURN_OBJ || rt == ERROR_OBJ {
-				return result
-			}
+		var stop bool
+		result, stop = e.loopControl(e.Eval(ws.Body), ws.Label)
+		if stop {
+			return result
 		}
 
 		condition = e.Eval(ws.Condition)
@@ -138,14 +169,11 @@ func (e *Evaluator) evalForStatement(fs *parser.ForStatement) Object {
 		iterations++
 
 		// Execute body
-		result = e.Eval(fs.Body)
-
-		if result != nil {
-			rt := result.Type()
-			if rt == RETURN_OBJ || rt == ERROR_OBJ {
-				e.env = outerEnv // Restore environment
-				return result
-			}
+		var stop bool
+		result, stop = e.loopControl(e.Eval(fs.Body), fs.Label)
+		if stop {
+			e.env = outerEnv // Restore environment
+			return result
 		}
 
 		// Update
@@ -172,6 +200,172 @@ func (e *Evaluator) evalForStatement(fs *parser.ForStatement) Object {
 	return result
 }
 
+// Evaluate a for-each statement. Each iteration gets a fresh scope holding
+// its bindings, so closures made in the body keep that iteration's values.
+func (e *Evaluator) evalForEachStatement(fs *parser.ForEachStatement) Object {
+	iterable := e.Eval(fs.Iterable)
+	if IsError(iterable) {
+		return iterable
+	}
+
+	outerEnv := e.env
+	defer func() { e.env = outerEnv }()
+
+	var result Object = e.NULL
+	err := iterate(iterable, func(key, val Object) bool {
+		e.env = NewEnclosedEnvironment(outerEnv)
+		if fs.Key != nil {
+			e.env.Set(fs.Key.Value, key)
+		}
+		e.env.Set(fs.Value.Value, val)
+
+		var stop bool
+		result, stop = e.loopControl(e.Eval(fs.Body), fs.Label)
+		return !stop
+	})
+	if err != nil {
+		return err
+	}
+
+	return result
+}
+
+// Call visit with the index or key and the value of each element of an
+// iterable, in order, until visit returns false. Arrays are walked as they
+// were when the loop started, strings by character, hashes in insertion
+// order, ranges one element at a time, generators as they yield and
+// channels as values arrive until they are closed. A generator left before
+// it finishes is stopped, and an error that finishes one is returned.
+func iterate(iterable Object, visit func(key, val Object) bool) *Error {
+	switch iterable := iterable.(type) {
+	case *Array:
+		for i, el := range iterable.Snapshot() {
+			if !visit(&Integer{Value: int64(i)}, el) {
+				break
+			}
+		}
+	case *String:
+		for i, ch := range []rune(iterable.Value) {
+			if !visit(&Integer{Value: int64(i)}, &String{Value: string(ch)}) {
+				break
+			}
+		}
+	case *Hash:
+		for _, pair := range iterable.Entries() {
+			// Skip keys deleted during the loop, and see values changed in it
+			val, ok := iterable.Get(pair.Key.(Hashable))
+			if !ok {
+				continue
+			}
+			if !visit(pair.Key, val) {
+				break
+			}
+		}
+	case *Generator:
+		for i := int64(0); ; i++ {
+			val, ok := iterable.Next()
+			if !ok {
+				if err, failed := val.(*Error); failed {
+					return err
+				}
+				break
+			}
+			if !visit(&Integer{Value: i}, val) {
+				iterable.Stop()
+				break
+			}
+		}
+	case *Channel:
+		for i := int64(0); ; i++ {
+			val, ok, err := iterable.Receive()
+			if err != nil {
+				return err
+			}
+			if !ok || !visit(&Integer{Value: i}, val) {
+				break
+			}
+		}
+	case *Range:
+		last, ok := iterable.Last()
+		for i := uint64(0); ok; i++ {
+			if !visit(rangePosition(i), &Integer{Value: iterable.At(i)}) || i == last {
+				break
+			}
+		}
+	default:
+		return newCodedError(errors.TYPE_MISMATCH, "cannot iterate over %s", iterable.Type())
+	}
+
+	return nil
+}
+
+// Evaluate a throw statement. Throwing a caught error re-raises it with its
+// original code; any other value becomes the message of a FAKE_NEWS error.
+func (e *Evaluator) evalThrowStatement(ts *parser.ThrowStatement) Object {
+	val := e.Eval(ts.Value)
+	if IsError(val) {
+		return val
+	}
+
+	if caught, ok := val.(*FakeNews); ok {
+		return newCodedError(caught.Code, "%s", caught.Message)
+	}
+
+	return newCodedError(errors.FAKE_NEWS, "%s", val.Inspect())
+}
+
+// Evaluate a try statement
+func (e *Evaluator) evalTryStatement(ts *parser.TryStatement) Object {
+	result := e.Eval(ts.Body)
+
+	if err, ok := result.(*Error); ok && ts.Catch != nil {
+		if ts.CatchParam != nil {
+			outerEnv := e.env
+			e.env = NewEnclosedEnvironment(outerEnv)
+			e.env.Set(ts.CatchParam.Value, &FakeNews{Code: err.Code, Message: err.Message})
+			result = e.Eval(ts.Catch)
+			e.env = outerEnv
+		} else {
+			result = e.Eval(ts.Catch)
+		}
+	}
+
+	if ts.Finally != nil {
+		// A return, error or loop signal from ANYWAY overrides the result
+		finally := e.Eval(ts.Finally)
+		if finally != nil {
+			switch finally.Type() {
+			case RETURN_OBJ, ERROR_OBJ, BREAK_OBJ, CONTINUE_OBJ:
+				return finally
+			}
+		}
+	}
+
+	return result
+}
+
+// Decide what a loop does with the result of one run of its body. It returns
+// the value to keep as the loop's result and whether the loop must stop,
+// in which case that value is what the loop returns.
+func (e *Evaluator) loopControl(result Object, label string) (Object, bool) {
+	switch result := result.(type) {
+	case *ReturnValue, *Error:
+		return result, true
+	case *Break:
+		if result.Label == "" || result.Label == label {
+			return e.NULL, true
+		}
+		return result, true
+	case *Continue:
+		if result.Label == "" || result.Label == label {
+			return e.NULL, false
+		}
+		return result, true
+	default:
+		return result, false
+	}
+}
+
 // Evaluate a tweet statement (print)
 func (e *Evaluator) evalTweetStatement(ts *parser.TweetStatement) Object {
 	val := e.Eval(ts.Value)
@@ -0,0 +1,278 @@
+// file: internal/interpreter/tasks.go
+// description: Concurrent tasks and channels for the TRUMP programming language
+
+package interpreter
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/AndrewDonelson/trumplang/internal/errors"
+	"github.com/AndrewDonelson/trumplang/internal/parser"
+)
+
+// How long every task must stay waiting, with no wait finishing, before the
+// program is taken to be deadlocked. It covers a task that has counted
+// itself as waiting but not started to wait yet.
+const deadlockGrace = 50 * time.Millisecond
+
+// taskGroup counts the tasks of a program, including its main code, and how
+// many of them are waiting in await, send, receive or select. Once all of
+// them are waiting none can ever go ahead, so each gets a DEADLOCK error
+// rather than the program hanging.
+type taskGroup struct {
+	mu       sync.Mutex
+	live     int           // Tasks that have not finished
+	blocked  int           // Tasks waiting
+	progress int           // Waits finished so far
+	checking bool          // Whether a possible deadlock is being confirmed
+	deadlock chan struct{} // Closed to wake every waiting task once deadlocked
+}
+
+func newTaskGroup() *taskGroup {
+	return &taskGroup{live: 1, deadlock: make(chan struct{})}
+}
+
+func (g *taskGroup) start() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.live++
+}
+
+func (g *taskGroup) finish() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.live--
+	g.check()
+}
+
+// Start confirming a deadlock if every task is waiting. The caller holds mu.
+func (g *taskGroup) check() {
+	if g.blocked > 0 && g.blocked >= g.live && !g.checking {
+		g.checking = true
+		go g.confirm(g.progress)
+	}
+}
+
+// Wake every waiting task if they are all still waiting, with none of their
+// waits finishing, once the grace period is over
+func (g *taskGroup) confirm(progress int) {
+	for {
+		time.Sleep(deadlockGrace)
+
+		g.mu.Lock()
+		switch {
+		case g.blocked == 0 || g.blocked < g.live:
+			g.checking = false
+			g.mu.Unlock()
+			return
+		case g.progress != progress:
+			progress = g.progress
+			g.mu.Unlock()
+		default:
+			close(g.deadlock)
+			g.deadlock = make(chan struct{})
+			g.checking = false
+			g.mu.Unlock()
+			return
+		}
+	}
+}
+
+// Carry out the first of some channel operations that can go ahead, waiting
+// for one if none can yet. It returns the index of the operation and what it
+// received, or a DEADLOCK error if every task ends up waiting.
+func (g *taskGroup) wait(cases []reflect.SelectCase) (int, reflect.Value, bool, *Error) {
+	ready := append(cases[:len(cases):len(cases)], reflect.SelectCase{Dir: reflect.SelectDefault})
+	if chosen, received, ok := reflect.Select(ready); chosen < len(cases) {
+		return chosen, received, ok, nil
+	}
+
+	g.mu.Lock()
+	g.blocked++
+	deadlock := g.deadlock
+	g.check()
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		g.blocked--
+		g.progress++
+		g.mu.Unlock()
+	}()
+
+	waiting := append(cases[:len(cases):len(cases)], reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(deadlock)})
+	if chosen, received, ok := reflect.Select(waiting); chosen < len(cases) {
+		return chosen, received, ok, nil
+	}
+
+	// One last try, in case an operation could go ahead as the deadlock was found
+	if chosen, received, ok := reflect.Select(ready); chosen < len(cases) {
+		return chosen, received, ok, nil
+	}
+	return 0, reflect.Value{}, false, newCodedError(errors.DEADLOCK, "deadlock: every task is waiting and none can go ahead")
+}
+
+// Task is a function call running concurrently, started by RALLY_TROOPS
+type Task struct {
+	Callee Object        // The function being called
+	done   chan struct{} // Closed once the call has finished
+	result Object
+	tasks  *taskGroup
+}
+
+func (t *Task) Type() string { return TASK_OBJ }
+func (t *Task) Inspect() string {
+	if fn, ok := t.Callee.(*Function); ok && fn.Name != "" {
+		return "TASK " + fn.Name
+	}
+	return "TASK"
+}
+
+// Wait blocks until the task has finished, returning the value its call
+// returned or the error it failed with
+func (t *Task) Wait() Object {
+	if _, _, _, err := t.tasks.wait([]reflect.SelectCase{{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(t.done)}}); err != nil {
+		return err
+	}
+	return t.result
+}
+
+// Evaluate a RALLY_TROOPS expression. The function and its arguments are
+// evaluated straight away; the call then runs on an evaluator of its own,
+// so it keeps its own current scope while it shares variables through the
+// closures it was given.
+func (e *Evaluator) evalSpawnExpression(se *parser.SpawnExpression) Object {
+	function := e.Eval(se.Call.Function)
+	if IsError(function) {
+		return function
+	}
+
+	switch function.(type) {
+	case *Function, *Builtin:
+	default:
+		return newError("not a function: %s", function.Type())
+	}
+
+	args := e.evalExpressions(se.Call.Arguments)
+	if len(args) == 1 && IsError(args[0]) {
+		return args[0]
+	}
+
+	task := &Task{Callee: function, done: make(chan struct{}), tasks: e.tasks}
+	troops := e.fork(e.env)
+
+	e.tasks.start()
+	go func() {
+		defer e.tasks.finish()
+		defer close(task.done)
+		task.result = troops.applyFunction(function, args)
+	}()
+
+	return task
+}
+
+// Channel passes values between tasks. A channel with a capacity holds up
+// to that many values until they are received; without one, each send waits
+// for a receiver.
+type Channel struct {
+	ch    chan Object
+	tasks *taskGroup
+}
+
+func (c *Channel) Type() string    { return CHANNEL_OBJ }
+func (c *Channel) Inspect() string { return "CHANNEL" }
+
+// Send waits until a value can be sent. Sending on a closed channel is an
+// error.
+func (c *Channel) Send(val Object) (err *Error) {
+	defer func() {
+		if recover() != nil {
+			err = newError("send on a closed CHANNEL")
+		}
+	}()
+
+	_, _, _, err = c.tasks.wait([]reflect.SelectCase{{Dir: reflect.SelectSend, Chan: reflect.ValueOf(c.ch), Send: reflect.ValueOf(val)}})
+	return err
+}
+
+// Receive waits for a value, reporting false once the channel has been
+// closed and every value sent on it received
+func (c *Channel) Receive() (Object, bool, *Error) {
+	_, received, ok, err := c.tasks.wait([]reflect.SelectCase{{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(c.ch)}})
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return received.Interface().(Object), true, nil
+}
+
+// Close stops any more values being sent. Values already sent can still be
+// received.
+func (c *Channel) Close() (err *Error) {
+	defer func() {
+		if recover() != nil {
+			err = newError("CHANNEL is already closed")
+		}
+	}()
+
+	close(c.ch)
+	return nil
+}
+
+// Wait for the first of several channel operations that can go ahead and
+// carry it out. Each case is a CHANNEL to receive from or a [CHANNEL, value]
+// pair to send on. It returns the index of the case taken and the value
+// received, which is COVFEFE for sends and closed channels. Unless block is
+// set, it returns -1 straight away when no case can go ahead.
+func (e *Evaluator) selectChannels(cases []Object, block bool) (chosen int, val Object, err *Error) {
+	selectCases := make([]reflect.SelectCase, 0, len(cases)+1)
+	for i, c := range cases {
+		switch c := c.(type) {
+		case *Channel:
+			selectCases = append(selectCases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(c.ch)})
+		case *Array:
+			pair := c.Snapshot()
+			var ch *Channel
+			if len(pair) == 2 {
+				ch, _ = pair[0].(*Channel)
+			}
+			if ch == nil {
+				return 0, nil, newError("select case %d must be a CHANNEL or a [CHANNEL, value] pair", i)
+			}
+			selectCases = append(selectCases, reflect.SelectCase{Dir: reflect.SelectSend, Chan: reflect.ValueOf(ch.ch), Send: reflect.ValueOf(pair[1])})
+		default:
+			return 0, nil, newError("select case %d must be a CHANNEL or a [CHANNEL, value] pair, got %s", i, c.Type())
+		}
+	}
+
+	if block && len(selectCases) == 0 {
+		return 0, nil, newError("select needs at least one case to wait for")
+	}
+
+	defer func() {
+		if recover() != nil {
+			err = newError("send on a closed CHANNEL")
+		}
+	}()
+
+	var received reflect.Value
+	var ok bool
+	if block {
+		if chosen, received, ok, err = e.tasks.wait(selectCases); err != nil {
+			return 0, nil, err
+		}
+	} else {
+		selectCases = append(selectCases, reflect.SelectCase{Dir: reflect.SelectDefault})
+		chosen, received, ok = reflect.Select(selectCases)
+	}
+
+	switch {
+	case chosen == len(cases):
+		return -1, e.NULL, nil
+	case ok:
+		return chosen, received.Interface().(Object), nil
+	default:
+		return chosen, e.NULL, nil
+	}
+}
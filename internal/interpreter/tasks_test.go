@@ -0,0 +1,82 @@
+// file: internal/interpreter/tasks_test.go
+// description: Tests for tasks sharing values; run them with go test -race
+
+package interpreter
+
+import "testing"
+
+// Four tasks that write to the same hash and array and read them back
+const sharedWork = `
+YUGE h = {};
+YUGE arr = [0, 0];
+YUGE FUNCTION work(id) {
+    MAKE AMERICA GREAT AGAIN FOR (i IN 1..50) {
+        h["k" + id + "_" + i] = i;
+        arr[0] = i;
+        arr[1] += 1;
+        YUGE seen = 0;
+        MAKE AMERICA GREAT AGAIN FOR (k, v IN h) { seen = seen + 1; }
+        seen = len(keys(h)) + len(arr) + first(arr);
+    }
+}
+await([RALLY_TROOPS work(1), RALLY_TROOPS work(2), RALLY_TROOPS work(3), RALLY_TROOPS work(4)]);
+`
+
+func TestTasksShareValues(t *testing.T) {
+	runEvalTests(t, []evalTest{
+		{"hash writes", sharedWork + `len(h);`, "200"},
+		{"array writes", sharedWork + `arr[0] >= 1 && arr[0] <= 50;`, "WINNING"},
+		{"hash reads", sharedWork + `h["k3_50"];`, "50"},
+		{
+			"push to a shared variable",
+			`YUGE list = [];
+			YUGE FUNCTION add(n) { MAKE AMERICA GREAT AGAIN FOR (i IN 1..50) { list = push(list, n); } }
+			await([RALLY_TROOPS add(1), RALLY_TROOPS add(2)]);
+			len(list) <= 100;`,
+			"WINNING",
+		},
+		{
+			"channel results",
+			`YUGE results = channel();
+			YUGE FUNCTION square(n) { send(results, n * n); }
+			MAKE AMERICA GREAT AGAIN FOR (n IN 1..4) { RALLY_TROOPS square(n); }
+			YUGE sum = 0;
+			MAKE AMERICA GREAT AGAIN FOR (n IN 1..4) { sum = sum + receive(results); }
+			sum;`,
+			"30",
+		},
+		{
+			"await a failed task",
+			`YUGE FUNCTION fail() { FAKE_NEWS "troops lost"; }
+			YUGE msg = "";
+			DENY { await(RALLY_TROOPS fail()); } BLAME (err) { msg = err["message"]; }
+			msg;`,
+			"troops lost",
+		},
+		{
+			"receive with no sender",
+			`YUGE c = channel();
+			YUGE code = "";
+			DENY { receive(c); } BLAME (err) { code = err["code"]; }
+			code;`,
+			"DEADLOCK",
+		},
+		{
+			"select with no sender",
+			`YUGE c = channel();
+			YUGE code = "";
+			DENY { select([c]); } BLAME (err) { code = err["code"]; }
+			code;`,
+			"DEADLOCK",
+		},
+		{
+			"await a stuck task",
+			`YUGE c = channel();
+			YUGE FUNCTION stuck() { RETURN receive(c); }
+			YUGE code = "";
+			DENY { await(RALLY_TROOPS stuck()); } BLAME (err) { code = err["code"]; }
+			code;`,
+			"DEADLOCK",
+		},
+	})
+}
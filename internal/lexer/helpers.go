@@ -4,6 +4,8 @@
 package lexer
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -47,6 +49,44 @@ func (l *Lexer) peekWord() string {
 	return word
 }
 
+// Check whether the input continues with the rest of a keyword phrase and
+// consume it if so. A space in rest matches one or more spaces or tabs, and
+// the phrase must end on a word boundary.
+func (l *Lexer) matchPhrase(rest string) bool {
+	if rest == "" || l.ch == 0 {
+		return false
+	}
+
+	i := l.position
+	for j := 0; j < len(rest); j++ {
+		if rest[j] == ' ' {
+			if i >= len(l.input) || (l.input[i] != ' ' && l.input[i] != '\t') {
+				return false
+			}
+			for i < len(l.input) && (l.input[i] == ' ' || l.input[i] == '\t') {
+				i++
+			}
+			continue
+		}
+		if i >= len(l.input) || l.input[i] != rest[j] {
+			return false
+		}
+		i++
+	}
+
+	if i < len(l.input) {
+		r, _ := utf8.DecodeRuneInString(l.input[i:])
+		if isLetter(r) || isDigit(r) {
+			return false
+		}
+	}
+
+	for l.position < i && l.ch != 0 {
+		l.readChar()
+	}
+	return true
+}
+
 // Read a word that might include colons (for FAKE NEWS:)
 func (l *Lexer) readWord() string {
 	position := l.position
@@ -121,9 +161,12 @@ func formatMultiLineComment(comment string) string {
 	return strings.Join(lines, "\n")
 }
 
-// Read a string literal
-func (l *Lexer) readString() string {
-	position := l.position + 1 // Skip the opening double quote
+// Read a string literal, or the segment of one that follows a ${...}
+// placeholder, decoding escape sequences into the returned value. It reports
+// whether the segment ended by opening a placeholder rather than at the
+// closing quote, leaving the lexer on the placeholder's '{'.
+func (l *Lexer) readString() (string, bool) {
+	var out strings.Builder
 	startLine := l.line
 	startColumn := l.column
 
@@ -132,9 +175,17 @@ func (l *Lexer) readString() string {
 		if l.ch == '"' || l.ch == 0 {
 			break
 		}
+
+		// Handle the start of a ${...} placeholder
+		if l.ch == '$' && l.peekChar() == '{' {
+			l.readChar()
+			return out.String(), true
+		}
+
 		// Handle escape sequences
 		if l.ch == '\\' {
-			l.readChar() // Skip the backslash
+			l.readEscape(&out)
+			continue
 		}
 
 		// Handle newlines in strings
@@ -142,6 +193,8 @@ func (l *Lexer) readString() string {
 			l.line++
 			l.column = 0
 		}
+
+		out.WriteRune(l.ch)
 	}
 
 	if l.ch == 0 {
@@ -149,32 +202,204 @@ func (l *Lexer) readString() string {
 		l.addError(errorMsg)
 	}
 
-	return l.input[position:l.position]
+	return out.String(), false
 }
 
-// Read a numeric literal (integer or float)
-func (l *Lexer) readNumber() token.Token {
-	startLine, startColumn := l.line, l.column
-	position := l.position
-	isFloat := false
+// Read raw text up to the closing delimiter, leaving the lexer on its last
+// character. No escape sequences are processed and the text may span lines;
+// carriage returns are dropped so the value does not depend on the file's
+// line endings.
+func (l *Lexer) readRaw(closing string) string {
+	var out strings.Builder
+	startLine := l.line
+	startColumn := l.column
 
-	for isDigit(l.ch) {
+	for {
 		l.readChar()
+		if l.ch == 0 {
+			errorMsg := errors.NewTrumpError(errors.UNTERMINATED_STRING, "Unterminated raw string", startLine, startColumn)
+			l.addError(errorMsg)
+			break
+		}
+
+		// Check for the closing delimiter
+		if strings.HasPrefix(l.input[l.position:], closing) {
+			for range closing[1:] {
+				l.readChar()
+			}
+			break
+		}
+
+		if l.ch == '\n' {
+			l.line++
+			l.column = 0
+		}
+
+		if l.ch != '\r' {
+			out.WriteRune(l.ch)
+		}
+	}
+
+	return out.String()
+}
+
+// Read a triple-quoted multi-line string, stripping the indentation shared
+// by its lines
+func (l *Lexer) readTripleQuotedString() string {
+	l.readChar() // Skip the second opening quote
+	l.readChar() // Skip the third opening quote
+	return dedent(l.readRaw(`"""`))
+}
+
+// Strip the indentation shared by every non-blank line of a triple-quoted
+// string. A line break straight after the opening quotes and the whitespace
+// before the closing quotes are dropped, so the delimiters can sit on their
+// own lines.
+func dedent(s string) string {
+	lines := strings.Split(s, "\n")
+	if len(lines) > 1 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	if len(lines) > 1 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	// Find the longest whitespace prefix common to all non-blank lines
+	indent := ""
+	first := true
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lead := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if first {
+			indent = lead
+			first = false
+			continue
+		}
+		for !strings.HasPrefix(lead, indent) {
+			indent = indent[:len(indent)-1]
+		}
 	}
 
-	// Check for decimal point
-	if l.ch == '.' && isDigit(l.peekChar()) {
-		isFloat = true
-		l.readChar() // Consume the decimal point
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+			continue
+		}
+		lines[i] = strings.TrimPrefix(line, indent)
+	}
 
-		for isDigit(l.ch) {
+	return strings.Join(lines, "\n")
+}
+
+// Decode the escape sequence starting at the current backslash and write it
+// to out. Invalid escapes are reported at the position of the backslash.
+func (l *Lexer) readEscape(out *strings.Builder) {
+	line, column := l.line, l.column
+
+	l.readChar() // Skip the backslash
+
+	switch l.ch {
+	case 'n':
+		out.WriteByte('\n')
+	case 't':
+		out.WriteByte('\t')
+	case 'r':
+		out.WriteByte('\r')
+	case '0':
+		out.WriteByte(0)
+	case '\\', '"', '$':
+		out.WriteRune(l.ch)
+	case 'x':
+		// \xHH: exactly two hex digits naming a code point up to U+00FF
+		digits := ""
+		for len(digits) < 2 && isHexDigit(l.peekChar()) {
 			l.readChar()
+			digits += string(l.ch)
+		}
+		if len(digits) != 2 {
+			l.addError(errors.NewTrumpError(errors.INVALID_ESCAPE, "Invalid \\x escape, expected two hex digits", line, column))
+			return
+		}
+		value, _ := strconv.ParseUint(digits, 16, 8)
+		out.WriteRune(rune(value))
+	case 'u':
+		// \u{H...}: one to six hex digits naming a Unicode code point
+		if l.peekChar() != '{' {
+			l.addError(errors.NewTrumpError(errors.INVALID_ESCAPE, "Invalid \\u escape, expected '{'", line, column))
+			return
+		}
+		l.readChar()
+		digits := ""
+		for isHexDigit(l.peekChar()) {
+			l.readChar()
+			digits += string(l.ch)
+		}
+		if l.peekChar() != '}' || len(digits) == 0 || len(digits) > 6 {
+			l.addError(errors.NewTrumpError(errors.INVALID_ESCAPE, "Invalid \\u escape, expected 1 to 6 hex digits in braces", line, column))
+			return
 		}
+		l.readChar() // Consume the closing brace
+		value, _ := strconv.ParseUint(digits, 16, 32)
+		if !utf8.ValidRune(rune(value)) {
+			l.addError(errors.NewTrumpError(errors.INVALID_ESCAPE, "Invalid \\u escape, not a valid Unicode code point", line, column))
+			return
+		}
+		out.WriteRune(rune(value))
+	case 0:
+		// Unterminated string, reported by the caller
+	default:
+		l.addError(errors.NewTrumpError(errors.INVALID_ESCAPE, fmt.Sprintf("Invalid escape sequence \\%c", l.ch), line, column))
+		if l.ch == '\n' {
+			l.line++
+			l.column = 0
+		}
+		out.WriteRune(l.ch)
 	}
+}
 
+// Read a numeric literal (integer or float)
+func (l *Lexer) readNumber() token.Token {
+	startLine, startColumn := l.line, l.column
+	position := l.position
+	errorCount := len(l.errors)
 	tokenType := token.INT
-	if isFloat {
-		tokenType = token.FLOAT
+
+	if l.ch == '0' && strings.ContainsRune("xXoObB", l.peekChar()) {
+		l.readPrefixedInteger()
+	} else {
+		l.readDigits(isDigit, "decimal")
+
+		// Check for decimal point, which may also start the literal
+		if l.ch == '.' && isDigit(l.peekChar()) {
+			tokenType = token.FLOAT
+			l.readChar() // Consume the decimal point
+			l.readDigits(isDigit, "decimal")
+		}
+
+		// Check for an exponent, e.g. 4.5e9 or 1E-3
+		if l.ch == 'e' || l.ch == 'E' {
+			tokenType = token.FLOAT
+			l.readChar() // Consume the 'e'
+			if l.ch == '+' || l.ch == '-' {
+				l.readChar()
+			}
+			if !isDigit(l.ch) {
+				l.numberError("exponent has no digits")
+			}
+			l.readDigits(isDigit, "decimal")
+		}
+	}
+
+	// A number must not run straight into a name, e.g. 12abc or 0b102
+	if isLetter(l.ch) || isDigit(l.ch) {
+		l.numberError(fmt.Sprintf("invalid character %q in number literal", l.ch))
+	}
+
+	// Malformed literals have been reported already
+	if len(l.errors) > errorCount {
+		tokenType = token.ILLEGAL
 	}
 
 	return token.Token{
@@ -185,6 +410,65 @@ func (l *Lexer) readNumber() token.Token {
 	}
 }
 
+// Read the digits of a 0x, 0o or 0b integer literal, starting at the '0'
+func (l *Lexer) readPrefixedInteger() {
+	l.readChar() // Consume the '0'
+
+	var valid func(rune) bool
+	var base string
+	switch l.ch {
+	case 'x', 'X':
+		valid, base = isHexDigit, "hexadecimal"
+	case 'o', 'O':
+		valid, base = isOctalDigit, "octal"
+	default:
+		valid, base = isBinaryDigit, "binary"
+	}
+
+	prefix := "0" + string(l.ch)
+	l.readChar() // Consume the base letter
+
+	if !valid(l.ch) {
+		if isDigit(l.ch) {
+			l.numberError(fmt.Sprintf("invalid digit %q in %s literal", l.ch, base))
+		} else {
+			l.numberError(fmt.Sprintf("%s must be followed by %s digits", prefix, base))
+		}
+		return
+	}
+
+	l.readDigits(valid, base)
+	if isDigit(l.ch) {
+		l.numberError(fmt.Sprintf("invalid digit %q in %s literal", l.ch, base))
+	}
+}
+
+// Read a run of digits accepted by valid, allowing single underscores
+// between them as separators, e.g. 1_000_000
+func (l *Lexer) readDigits(valid func(rune) bool, base string) {
+	for valid(l.ch) {
+		l.readChar()
+
+		if l.ch == '_' {
+			l.readChar()
+			if !valid(l.ch) {
+				l.numberError(fmt.Sprintf("'_' must separate %s digits", base))
+			}
+		}
+	}
+}
+
+// Report a malformed number literal at the current character, then skip
+// the rest of it so one mistake gives one error
+func (l *Lexer) numberError(msg string) {
+	errorMsg := errors.NewTrumpError(errors.INVALID_NUMBER, msg, l.line, l.column)
+	l.addError(errorMsg)
+
+	for isLetter(l.ch) || isDigit(l.ch) {
+		l.readChar()
+	}
+}
+
 // Read an identifier
 func (l *Lexer) readIdentifier() string {
 	position := l.position
@@ -230,7 +514,7 @@ func (l *Lexer) peekChar() rune {
 
 // Add an error to the lexer's error list
 func (l *Lexer) addError(err string) {
-	l.errors = append(l.errors, err)
+	l.errors = append(l.errors, errors.InFile(l.file, err))
 }
 
 // Helper function to create a new token
@@ -238,12 +522,37 @@ func newToken(tokenType token.TokenType, ch rune, line, column int) token.Token
 	return token.Token{Type: tokenType, Literal: string(ch), Line: line, Column: column}
 }
 
+// Build a two-character operator token from the current and next character,
+// advancing the lexer past the first one
+func (l *Lexer) newTwoCharToken(tokenType token.TokenType) token.Token {
+	ch := l.ch
+	l.readChar()
+	literal := string(ch) + string(l.ch)
+	return token.Token{Type: tokenType, Literal: literal, Line: l.line, Column: l.column - 1}
+}
+
 // Helper function to check if a character is a letter
 func isLetter(ch rune) bool {
 	return unicode.IsLetter(ch) || ch == '_'
 }
 
-// Helper function to check if a character is a digit
+// Helper function to check if a character is a hexadecimal digit
+func isHexDigit(ch rune) bool {
+	return ('0' <= ch && ch <= '9') || ('a' <= ch && ch <= 'f') || ('A' <= ch && ch <= 'F')
+}
+
+// Helper function to check if a character is a decimal digit. Only ASCII
+// digits count, so literals always parse with strconv.
 func isDigit(ch rune) bool {
-	return unicode.IsDigit(ch)
+	return '0' <= ch && ch <= '9'
+}
+
+// Helper function to check if a character is an octal digit
+func isOctalDigit(ch rune) bool {
+	return '0' <= ch && ch <= '7'
+}
+
+// Helper function to check if a character is a binary digit
+func isBinaryDigit(ch rune) bool {
+	return ch == '0' || ch == '1'
 }
@@ -4,6 +4,8 @@
 package lexer
 
 import (
+	"strings"
+
 	"github.com/AndrewDonelson/trumplang/internal/errors"
 	"github.com/AndrewDonelson/trumplang/internal/lexer/token"
 )
@@ -11,12 +13,16 @@ import (
 // Lexer represents a lexical analyzer for the TRUMP programming language
 type Lexer struct {
 	input        string   // Source code input
+	file         string   // Source file name attached to tokens and errors
 	position     int      // Current position in input (points to current char)
 	readPosition int      // Current reading position in input (after current char)
 	ch           rune     // Current character being examined
 	line         int      // Current line number
 	column       int      // Current column number
 	errors       []string // Encountered errors
+
+	// Brace depth inside each open ${...} string placeholder, innermost last
+	interpolations []int
 }
 
 // New creates a new Lexer instance
@@ -31,6 +37,14 @@ func New(input string) *Lexer {
 	return l
 }
 
+// NewWithFile creates a new Lexer whose tokens and errors carry the name of
+// the file the input was read from
+func NewWithFile(input, file string) *Lexer {
+	l := New(input)
+	l.file = file
+	return l
+}
+
 // Errors returns the list of errors encountered during lexing
 func (l *Lexer) Errors() []string {
 	return l.errors
@@ -38,6 +52,13 @@ func (l *Lexer) Errors() []string {
 
 // NextToken scans the next token from the input
 func (l *Lexer) NextToken() token.Token {
+	tok := l.readToken()
+	tok.File = l.file
+	return tok
+}
+
+// Scan the next token from the input
+func (l *Lexer) readToken() token.Token {
 	var tok token.Token
 
 	l.skipWhitespace()
@@ -53,13 +74,23 @@ func (l *Lexer) NextToken() token.Token {
 			l.readChar()
 			literal := string(ch) + string(l.ch)
 			tok = token.Token{Type: token.EQ, Literal: literal, Line: l.line, Column: l.column - 1}
+		} else if l.peekChar() == '>' {
+			tok = l.newTwoCharToken(token.ARROW)
 		} else {
 			tok = newToken(token.ASSIGN, l.ch, l.line, l.column)
 		}
 	case '+':
-		tok = newToken(token.PLUS, l.ch, l.line, l.column)
+		if l.peekChar() == '=' {
+			tok = l.newTwoCharToken(token.PLUS_ASSIGN)
+		} else {
+			tok = newToken(token.PLUS, l.ch, l.line, l.column)
+		}
 	case '-':
-		tok = newToken(token.MINUS, l.ch, l.line, l.column)
+		if l.peekChar() == '=' {
+			tok = l.newTwoCharToken(token.MINUS_ASSIGN)
+		} else {
+			tok = newToken(token.MINUS, l.ch, l.line, l.column)
+		}
 	case '!':
 		if l.peekChar() == '=' {
 			ch := l.ch
@@ -74,9 +105,17 @@ func (l *Lexer) NextToken() token.Token {
 		if l.peekChar() == '/' {
 			l.readChar()        // Skip '*'
 			l.readChar()        // Skip '/'
-			tok = l.NextToken() // Skip the comment end and return the next token
+			tok = l.readToken() // Skip the comment end and return the next token
 			return tok
 		}
+		if l.peekChar() == '*' {
+			tok = l.newTwoCharToken(token.POWER)
+			break
+		}
+		if l.peekChar() == '=' {
+			tok = l.newTwoCharToken(token.ASTERISK_ASSIGN)
+			break
+		}
 		tok = newToken(token.ASTERISK, l.ch, l.line, l.column)
 	case '/':
 		// Check for single-line comment
@@ -93,13 +132,39 @@ func (l *Lexer) NextToken() token.Token {
 			tok = token.Token{Type: token.COMMENT, Literal: comment, Line: l.line, Column: l.column - 1}
 			return tok
 		}
+		if l.peekChar() == '=' {
+			tok = l.newTwoCharToken(token.SLASH_ASSIGN)
+			break
+		}
 		tok = newToken(token.SLASH, l.ch, l.line, l.column)
+	case '&':
+		if l.peekChar() == '&' {
+			tok = l.newTwoCharToken(token.AND)
+		} else {
+			tok = newToken(token.BIT_AND, l.ch, l.line, l.column)
+		}
+	case '|':
+		if l.peekChar() == '|' {
+			tok = l.newTwoCharToken(token.OR)
+		} else {
+			tok = newToken(token.BIT_OR, l.ch, l.line, l.column)
+		}
+	case '^':
+		tok = newToken(token.BIT_XOR, l.ch, l.line, l.column)
+	case '%':
+		tok = newToken(token.PERCENT, l.ch, l.line, l.column)
+	case '~':
+		tok = newToken(token.TILDE, l.ch, l.line, l.column)
+	case '?':
+		tok = newToken(token.QUESTION, l.ch, l.line, l.column)
 	case '<':
 		if l.peekChar() == '=' {
 			ch := l.ch
 			l.readChar()
 			literal := string(ch) + string(l.ch)
 			tok = token.Token{Type: token.LT_EQ, Literal: literal, Line: l.line, Column: l.column - 1}
+		} else if l.peekChar() == '<' {
+			tok = l.newTwoCharToken(token.SHIFT_LEFT)
 		} else {
 			tok = newToken(token.LT, l.ch, l.line, l.column)
 		}
@@ -109,6 +174,8 @@ func (l *Lexer) NextToken() token.Token {
 			l.readChar()
 			literal := string(ch) + string(l.ch)
 			tok = token.Token{Type: token.GT_EQ, Literal: literal, Line: l.line, Column: l.column - 1}
+		} else if l.peekChar() == '>' {
+			tok = l.newTwoCharToken(token.SHIFT_RIGHT)
 		} else {
 			tok = newToken(token.GT, l.ch, l.line, l.column)
 		}
@@ -116,23 +183,77 @@ func (l *Lexer) NextToken() token.Token {
 		tok = newToken(token.SEMICOLON, l.ch, l.line, l.column)
 	case ',':
 		tok = newToken(token.COMMA, l.ch, l.line, l.column)
+	case '.':
+		rest := l.input[l.position:]
+		switch {
+		case isDigit(l.peekChar()):
+			// A float with a leading dot, e.g. .5
+			return l.readNumber()
+		case strings.HasPrefix(rest, "..."):
+			tok.Type, tok.Literal = token.ELLIPSIS, "..."
+			l.readChar()
+			l.readChar()
+		case strings.HasPrefix(rest, "..<"):
+			tok.Type, tok.Literal = token.DOTDOT_LT, "..<"
+			l.readChar()
+			l.readChar()
+		case strings.HasPrefix(rest, ".."):
+			tok = l.newTwoCharToken(token.DOTDOT)
+		default:
+			tok = newToken(token.ILLEGAL, l.ch, l.line, l.column)
+			errorMsg := errors.NewTrumpError(errors.ILLEGAL_CHARACTER, "Illegal character found", l.line, l.column)
+			l.addError(errorMsg)
+		}
+	case ':':
+		tok = newToken(token.COLON, l.ch, l.line, l.column)
 	case '(':
 		tok = newToken(token.LPAREN, l.ch, l.line, l.column)
 	case ')':
 		tok = newToken(token.RPAREN, l.ch, l.line, l.column)
 	case '{':
+		if n := len(l.interpolations); n > 0 {
+			l.interpolations[n-1]++
+		}
 		tok = newToken(token.LBRACE, l.ch, l.line, l.column)
 	case '}':
+		n := len(l.interpolations)
+		if n > 0 && l.interpolations[n-1] == 0 {
+			// Closing a ${...} placeholder, so resume the string
+			l.interpolations = l.interpolations[:n-1]
+			literal, open := l.readString()
+			tok.Literal = literal
+			tok.Type = token.STRING_END
+			if open {
+				tok.Type = token.STRING_MID
+				l.interpolations = append(l.interpolations, 0)
+			}
+			break
+		}
+		if n > 0 {
+			l.interpolations[n-1]--
+		}
 		tok = newToken(token.RBRACE, l.ch, l.line, l.column)
 	case '[':
 		tok = newToken(token.LBRACKET, l.ch, l.line, l.column)
 	case ']':
 		tok = newToken(token.RBRACKET, l.ch, l.line, l.column)
+	case '`':
+		tok.Type = token.STRING
+		tok.Literal = l.readRaw("`")
 	case '"':
+		// Position stays at the opening quote
+		if strings.HasPrefix(l.input[l.position:], `"""`) {
+			tok.Type = token.STRING
+			tok.Literal = l.readTripleQuotedString()
+			break
+		}
+		literal, open := l.readString()
+		tok.Literal = literal
 		tok.Type = token.STRING
-		tok.Literal = l.readString()
-		tok.Line = l.line
-		tok.Column = l.column - len(tok.Literal) - 1 // Adjust for the opening quote and string length
+		if open {
+			tok.Type = token.STRING_START
+			l.interpolations = append(l.interpolations, 0)
+		}
 	case 0:
 		tok.Literal = ""
 		tok.Type = token.EOF
@@ -149,15 +270,20 @@ func (l *Lexer) NextToken() token.Token {
 				return tok
 			}
 
+			// Check for multi-word keywords such as YOU'RE FIRED
+			for phrase, phraseType := range token.KeywordPhrases {
+				if strings.HasPrefix(phrase, identifier) && l.matchPhrase(phrase[len(identifier):]) {
+					return token.Token{Type: phraseType, Literal: phrase, Line: tok.Line, Column: tok.Column}
+				}
+			}
+
 			tok.Type = token.LookupIdent(identifier)
 			tok.Line = l.line
 			tok.Column = l.column - len(identifier)
 			return tok
 		} else if isDigit(l.ch) {
-			numToken := l.readNumber()
-			numToken.Line = l.line
-			numToken.Column = l.column - len(numToken.Literal)
-			return numToken
+			// Numbers report the position of their first character
+			return l.readNumber()
 		} else {
 			tok = newToken(token.ILLEGAL, l.ch, l.line, l.column)
 			errorMsg := errors.NewTrumpError(errors.ILLEGAL_CHARACTER, "Illegal character found", l.line, l.column)
@@ -10,6 +10,7 @@ type TokenType string
 type Token struct {
 	Type    TokenType
 	Literal string
+	File    string // Source file name, empty when lexing a plain string
 	Line    int
 	Column  int
 }
@@ -27,13 +28,34 @@ const (
 	FLOAT  = "FLOAT"  // Float literal
 	STRING = "STRING" // String literal
 
+	// Interpolated string segments: "a ${x} b ${y} c" lexes as
+	// STRING_START("a "), x, STRING_MID(" b "), y, STRING_END(" c")
+	STRING_START = "STRING_START"
+	STRING_MID   = "STRING_MID"
+	STRING_END   = "STRING_END"
+
 	// Operators
-	ASSIGN   = "=" // =
-	PLUS     = "+" // +
-	MINUS    = "-" // -
-	BANG     = "!" // !
-	ASTERISK = "*" // *
-	SLASH    = "/" // /
+	ASSIGN   = "="  // =
+	PLUS     = "+"  // +
+	MINUS    = "-"  // -
+	BANG     = "!"  // !
+	ASTERISK = "*"  // *
+	SLASH    = "/"  // /
+	PERCENT  = "%"  // %
+	POWER    = "**" // **
+	TILDE    = "~"  // ~
+	QUESTION = "?"  // ? in a conditional expression
+
+	BIT_AND     = "&"  // &
+	BIT_OR      = "|"  // |
+	BIT_XOR     = "^"  // ^
+	SHIFT_LEFT  = "<<" // <<
+	SHIFT_RIGHT = ">>" // >>
+
+	PLUS_ASSIGN     = "+=" // +=
+	MINUS_ASSIGN    = "-=" // -=
+	ASTERISK_ASSIGN = "*=" // *=
+	SLASH_ASSIGN    = "/=" // /=
 
 	EQ     = "==" // ==
 	NOT_EQ = "!=" // !=
@@ -42,15 +64,23 @@ const (
 	LT_EQ  = "<=" // <=
 	GT_EQ  = ">=" // >=
 
+	AND = "&&" // && or AND
+	OR  = "||" // || or OR
+
 	// Delimiters
-	COMMA     = "," // ,
-	SEMICOLON = ";" // ;
-	LPAREN    = "(" // (
-	RPAREN    = ")" // )
-	LBRACE    = "{" // {
-	RBRACE    = "}" // }
-	LBRACKET  = "[" // [
-	RBRACKET  = "]" // ]
+	COMMA     = ","   // ,
+	COLON     = ":"   // :
+	SEMICOLON = ";"   // ;
+	LPAREN    = "("   // (
+	RPAREN    = ")"   // )
+	LBRACE    = "{"   // {
+	RBRACE    = "}"   // }
+	LBRACKET  = "["   // [
+	RBRACKET  = "]"   // ]
+	ARROW     = "=>"  // =>
+	DOTDOT    = ".."  // ..
+	DOTDOT_LT = "..<" // ..<
+	ELLIPSIS  = "..." // ...
 
 	// Keywords
 	FUNCTION        = "FUNCTION"
@@ -78,6 +108,21 @@ const (
 	AMERICA         = "AMERICA"
 	GREAT           = "GREAT"
 	AGAIN           = "AGAIN"
+	NOT             = "NOT"
+	DENY            = "DENY"
+	BLAME           = "BLAME"
+	ANYWAY          = "ANYWAY"
+	IMPORT          = "IMPORT"
+	AS              = "AS"
+	NEGOTIATE       = "NEGOTIATE"
+	IN              = "IN"
+	BY              = "BY"
+	YIELD           = "YIELD"
+	RALLY_TROOPS    = "RALLY_TROOPS"
+
+	// Multi-word keywords
+	BREAK    = "BREAK"    // YOU'RE FIRED
+	CONTINUE = "CONTINUE" // NEXT DEAL
 )
 
 // Map of keywords to their token types
@@ -107,6 +152,27 @@ var keywords = map[string]TokenType{
 	"AMERICA":         AMERICA,
 	"GREAT":           GREAT,
 	"AGAIN":           AGAIN,
+	"AND":             AND,
+	"OR":              OR,
+	"NOT":             NOT,
+	"DENY":            DENY,
+	"BLAME":           BLAME,
+	"ANYWAY":          ANYWAY,
+	"IMPORT":          IMPORT,
+	"AS":              AS,
+	"NEGOTIATE":       NEGOTIATE,
+	"IN":              IN,
+	"BY":              BY,
+	"YIELD":           YIELD,
+	"RALLY_TROOPS":    RALLY_TROOPS,
+}
+
+// Keyword phrases span several words but are lexed as a single token.
+// Words are separated by a single space here and by any run of spaces or
+// tabs in source code.
+var KeywordPhrases = map[string]TokenType{
+	"YOU'RE FIRED": BREAK,
+	"NEXT DEAL":    CONTINUE,
 }
 
 // LookupIdent checks if the given identifier is a keyword
@@ -30,6 +30,7 @@ type Expression interface {
 // Program represents the entire program
 type Program struct {
 	Statements []Statement
+	Bigly      bool // Set by a leading "BIGLY;" pragma
 }
 
 func (p *Program) TokenLiteral() string {
@@ -42,6 +43,10 @@ func (p *Program) TokenLiteral() string {
 func (p *Program) String() string {
 	var out bytes.Buffer
 
+	if p.Bigly {
+		out.WriteString("BIGLY;")
+	}
+
 	for _, s := range p.Statements {
 		out.WriteString(s.String())
 	}
@@ -54,16 +59,23 @@ func (p *Program) String() string {
 type LetStatement struct {
 	Token token.Token // YUGE or TREMENDOUS
 	Name  *Identifier
+	Type  string // Optional type annotation, empty when dynamically typed
 	Value Expression
 }
 
 func (ls *LetStatement) statementNode()       {}
 func (ls *LetStatement) TokenLiteral() string { return ls.Token.Literal }
+
+// Constant reports whether the statement declares a TREMENDOUS constant
+func (ls *LetStatement) Constant() bool { return ls.Token.Type == token.TREMENDOUS }
 func (ls *LetStatement) String() string {
 	var out bytes.Buffer
 
 	out.WriteString(ls.TokenLiteral() + " ")
 	out.WriteString(ls.Name.String())
+	if ls.Type != "" {
+		out.WriteString(": " + ls.Type)
+	}
 	out.WriteString(" = ")
 
 	if ls.Value != nil {
@@ -75,6 +87,22 @@ func (ls *LetStatement) String() string {
 	return out.String()
 }
 
+// FunctionDeclaration represents a named function declaration, which is
+// hoisted to the top of its enclosing program or block
+// e.g., "YUGE FUNCTION greet(name) RATED 10/10 { ... }"
+type FunctionDeclaration struct {
+	Token    token.Token // YUGE or TREMENDOUS
+	Name     *Identifier
+	Function *FunctionLiteral
+}
+
+func (fd *FunctionDeclaration) statementNode()       {}
+func (fd *FunctionDeclaration) TokenLiteral() string { return fd.Token.Literal }
+func (fd *FunctionDeclaration) String() string       { return fd.Function.String() }
+
+// Constant reports whether the function is declared with TREMENDOUS
+func (fd *FunctionDeclaration) Constant() bool { return fd.Token.Type == token.TREMENDOUS }
+
 // ReturnStatement represents a return statement
 // e.g., "RETURN x;"
 type ReturnStatement struct {
@@ -81,3 +81,63 @@ func (ie *IndexExpression) String() string {
 
 	return out.String()
 }
+
+// SliceExpression represents a slice of a string or array, where either
+// bound may be omitted
+// e.g., "myString[1:3]", "myArray[:2]"
+type SliceExpression struct {
+	Token token.Token // The '[' token
+	Left  Expression
+	Start Expression // nil when omitted
+	End   Expression // nil when omitted
+}
+
+func (se *SliceExpression) expressionNode()      {}
+func (se *SliceExpression) TokenLiteral() string { return se.Token.Literal }
+func (se *SliceExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(se.Left.String())
+	out.WriteString("[")
+	if se.Start != nil {
+		out.WriteString(se.Start.String())
+	}
+	out.WriteString(":")
+	if se.End != nil {
+		out.WriteString(se.End.String())
+	}
+	out.WriteString("])")
+
+	return out.String()
+}
+
+// HashPair is a single key/value entry in a hash literal
+type HashPair struct {
+	Key   Expression
+	Value Expression
+}
+
+// HashLiteral represents a hash map literal, keeping pairs in source order
+// e.g., "{"name": "Trump", 45: WINNING}"
+type HashLiteral struct {
+	Token token.Token // the '{' token
+	Pairs []HashPair
+}
+
+func (hl *HashLiteral) expressionNode()      {}
+func (hl *HashLiteral) TokenLiteral() string { return hl.Token.Literal }
+func (hl *HashLiteral) String() string {
+	var out bytes.Buffer
+
+	pairs := []string{}
+	for _, pair := range hl.Pairs {
+		pairs = append(pairs, pair.Key.String()+": "+pair.Value.String())
+	}
+
+	out.WriteString("{")
+	out.WriteString(strings.Join(pairs, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
@@ -6,7 +6,9 @@ package parser
 import (
 	"bytes"
 	"fmt"
+	"math/big"
 	"strings"
+	"unicode"
 
 	"github.com/AndrewDonelson/trumplang/internal/lexer/token"
 )
@@ -31,6 +33,28 @@ func (il *IntegerLiteral) expressionNode()      {}
 func (il *IntegerLiteral) TokenLiteral() string { return il.Token.Literal }
 func (il *IntegerLiteral) String() string       { return il.Token.Literal }
 
+// BigIntegerLiteral represents an integer literal too large for 64 bits,
+// only allowed in BIGLY files
+type BigIntegerLiteral struct {
+	Token token.Token // the token.INT token
+	Value *big.Int
+}
+
+func (bl *BigIntegerLiteral) expressionNode()      {}
+func (bl *BigIntegerLiteral) TokenLiteral() string { return bl.Token.Literal }
+func (bl *BigIntegerLiteral) String() string       { return bl.Token.Literal }
+
+// DecimalLiteral represents an exact decimal number marked with BILLIONS
+// e.g., "12.50 BILLIONS"
+type DecimalLiteral struct {
+	Token token.Token // the token.INT or token.FLOAT token
+	Value string      // The number as written
+}
+
+func (dl *DecimalLiteral) expressionNode()      {}
+func (dl *DecimalLiteral) TokenLiteral() string { return dl.Token.Literal }
+func (dl *DecimalLiteral) String() string       { return dl.Value + " BILLIONS" }
+
 // FloatLiteral represents a floating-point literal
 type FloatLiteral struct {
 	Token token.Token // the token.FLOAT token
@@ -49,7 +73,92 @@ type StringLiteral struct {
 
 func (sl *StringLiteral) expressionNode()      {}
 func (sl *StringLiteral) TokenLiteral() string { return sl.Token.Literal }
-func (sl *StringLiteral) String() string       { return fmt.Sprintf("\"%s\"", sl.Value) }
+func (sl *StringLiteral) String() string       { return quoteString(sl.Value) }
+
+// InterpolatedString represents a string literal with embedded expressions
+// e.g., "Hello ${name}, you have ${len(deals)} deals"
+type InterpolatedString struct {
+	Token token.Token  // the token.STRING_START token
+	Parts []Expression // *StringLiteral segments alternating with *Placeholder
+}
+
+func (is *InterpolatedString) expressionNode()      {}
+func (is *InterpolatedString) TokenLiteral() string { return is.Token.Literal }
+func (is *InterpolatedString) String() string {
+	var out bytes.Buffer
+
+	out.WriteByte('"')
+	for _, part := range is.Parts {
+		switch part := part.(type) {
+		case *StringLiteral:
+			out.WriteString(escapeString(part.Value))
+		default:
+			out.WriteString(part.String())
+		}
+	}
+	out.WriteByte('"')
+
+	return out.String()
+}
+
+// Placeholder represents an expression embedded in an interpolated string
+// e.g., "${name}"
+type Placeholder struct {
+	Token token.Token // The first token of the embedded expression
+	Value Expression
+}
+
+func (ph *Placeholder) expressionNode()      {}
+func (ph *Placeholder) TokenLiteral() string { return ph.Token.Literal }
+func (ph *Placeholder) String() string {
+	if ph.Value == nil {
+		return "${}"
+	}
+	return "${" + ph.Value.String() + "}"
+}
+
+// Quote a string value as TRUMP source
+func quoteString(s string) string {
+	return "\"" + escapeString(s) + "\""
+}
+
+// Escape anything in a string value that readString would otherwise decode
+// or that is not printable
+func escapeString(s string) string {
+	var out strings.Builder
+
+	runes := []rune(s)
+	for i, r := range runes {
+		switch r {
+		case '\\':
+			out.WriteString(`\\`)
+		case '"':
+			out.WriteString(`\"`)
+		case '\n':
+			out.WriteString(`\n`)
+		case '\t':
+			out.WriteString(`\t`)
+		case '\r':
+			out.WriteString(`\r`)
+		case 0:
+			out.WriteString(`\0`)
+		case '$':
+			if i+1 < len(runes) && runes[i+1] == '{' {
+				out.WriteString(`\$`)
+			} else {
+				out.WriteRune(r)
+			}
+		default:
+			if unicode.IsPrint(r) {
+				out.WriteRune(r)
+			} else {
+				out.WriteString(fmt.Sprintf("\\u{%x}", r))
+			}
+		}
+	}
+
+	return out.String()
+}
 
 // BooleanLiteral represents a boolean literal (WINNING or LOSER)
 type BooleanLiteral struct {
@@ -61,30 +170,50 @@ func (bl *BooleanLiteral) expressionNode()      {}
 func (bl *BooleanLiteral) TokenLiteral() string { return bl.Token.Literal }
 func (bl *BooleanLiteral) String() string       { return bl.Token.Literal }
 
-// FunctionLiteral represents a function definition
-// e.g., "YUGE FUNCTION add(x, y) RATED 10/10 { ... }"
+// FunctionLiteral represents a function definition or lambda
+// e.g., "YUGE FUNCTION add(x, y) RATED 10/10 { ... }" or "(x) => x * 2"
 type FunctionLiteral struct {
-	Token      token.Token // The 'FUNCTION' token
-	Parameters []*Identifier
-	Body       *BlockStatement
-	Rating     string // Optional rating (e.g., "10/10")
+	Token          token.Token // The 'FUNCTION' token, or '(' for lambdas
+	Name           string      // Set for named declarations, empty for anonymous functions
+	Parameters     []*Identifier
+	ParameterTypes []string // Optional type annotation of each parameter, empty when dynamically typed
+	ReturnType     string   // Optional return type annotation
+	Body           *BlockStatement
+	Rating         string // Optional rating (e.g., "10/10")
+	Lambda         bool   // Written with "=>"
+	Generator      bool   // Whether its body contains YIELD
 }
 
 func (fl *FunctionLiteral) expressionNode()      {}
 func (fl *FunctionLiteral) TokenLiteral() string { return fl.Token.Literal }
 func (fl *FunctionLiteral) String() string {
+	if fl.Lambda {
+		return LambdaString(fl.Parameters, fl.ParameterTypes, fl.Body)
+	}
+
 	var out bytes.Buffer
 
 	params := []string{}
-	for _, p := range fl.Parameters {
-		params = append(params, p.String())
+	for i, p := range fl.Parameters {
+		if i < len(fl.ParameterTypes) && fl.ParameterTypes[i] != "" {
+			params = append(params, p.String()+": "+fl.ParameterTypes[i])
+		} else {
+			params = append(params, p.String())
+		}
 	}
 
 	out.WriteString("YUGE ")
 	out.WriteString(fl.TokenLiteral())
+	if fl.Name != "" {
+		out.WriteString(" " + fl.Name)
+	}
 	out.WriteString("(")
 	out.WriteString(strings.Join(params, ", "))
-	out.WriteString(") ")
+	out.WriteString(")")
+	if fl.ReturnType != "" {
+		out.WriteString(": " + fl.ReturnType)
+	}
+	out.WriteString(" ")
 
 	if fl.Rating != "" {
 		out.WriteString("RATED ")
@@ -97,6 +226,106 @@ func (fl *FunctionLiteral) String() string {
 	return out.String()
 }
 
+// LambdaString renders a lambda the way it is written, showing an
+// expression body without the return it is stored as
+func LambdaString(params []*Identifier, types []string, body *BlockStatement) string {
+	names := []string{}
+	for i, p := range params {
+		if i < len(types) && types[i] != "" {
+			names = append(names, p.String()+": "+types[i])
+		} else {
+			names = append(names, p.String())
+		}
+	}
+
+	out := "(" + strings.Join(names, ", ") + ") => "
+
+	if len(body.Statements) == 1 {
+		if ret, ok := body.Statements[0].(*ReturnStatement); ok && ret.Token.Type == token.ARROW {
+			return out + ret.ReturnValue.String()
+		}
+	}
+
+	// Separate the statements of a block body so that it reads on one line
+	statements := make([]string, 0, len(body.Statements))
+	for _, s := range body.Statements {
+		str := s.String()
+		if !strings.HasSuffix(str, ";") && !strings.HasSuffix(str, "}") {
+			str += ";"
+		}
+		statements = append(statements, str)
+	}
+	if len(statements) == 0 {
+		return out + "{ }"
+	}
+
+	return out + "{ " + strings.Join(statements, " ") + " }"
+}
+
+// ConditionalExpression chooses between two values
+// e.g., "votes > 270 ? "WINNING" : "RIGGED""
+type ConditionalExpression struct {
+	Token       token.Token // the '?' token
+	Condition   Expression
+	Consequence Expression
+	Alternative Expression
+}
+
+func (ce *ConditionalExpression) expressionNode()      {}
+func (ce *ConditionalExpression) TokenLiteral() string { return ce.Token.Literal }
+func (ce *ConditionalExpression) String() string {
+	return "(" + ce.Condition.String() + " ? " + ce.Consequence.String() + " : " + ce.Alternative.String() + ")"
+}
+
+// RangeExpression represents a range of integers, with an optional step
+// e.g., "0..10", "0..<10" or "10..0 BY -2"
+type RangeExpression struct {
+	Token     token.Token // the '..' or '..<' token
+	Start     Expression
+	End       Expression
+	Step      Expression // nil if absent
+	Exclusive bool       // Whether End is left out
+}
+
+func (re *RangeExpression) expressionNode()      {}
+func (re *RangeExpression) TokenLiteral() string { return re.Token.Literal }
+func (re *RangeExpression) String() string {
+	out := "(" + re.Start.String() + re.Token.Literal + re.End.String()
+	if re.Step != nil {
+		out += " BY " + re.Step.String()
+	}
+	return out + ")"
+}
+
+// IfExpression is a BUILD WALL IF used as a value. It evaluates to the
+// last value of the branch taken, or COVFEFE if no branch is taken.
+// e.g., "BUILD WALL IF (x > 5) { "big" } ELSE { "small" }"
+type IfExpression struct {
+	Token       token.Token // the 'BUILD' token
+	Condition   Expression
+	Consequence *BlockStatement
+	Alternative *BlockStatement
+}
+
+func (ie *IfExpression) expressionNode()      {}
+func (ie *IfExpression) TokenLiteral() string { return ie.Token.Literal }
+func (ie *IfExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("BUILD WALL IF ")
+	out.WriteString("(")
+	out.WriteString(ie.Condition.String())
+	out.WriteString(") ")
+	out.WriteString(ie.Consequence.String())
+
+	if ie.Alternative != nil {
+		out.WriteString(" ELSE ")
+		out.WriteString(ie.Alternative.String())
+	}
+
+	return out.String()
+}
+
 // PrefixExpression represents a prefix operator expression
 // e.g., "!x" or "-5"
 type PrefixExpression struct {
@@ -118,6 +347,19 @@ func (pe *PrefixExpression) String() string {
 	return out.String()
 }
 
+// SpawnExpression starts a function call as a concurrent task
+// e.g., "RALLY_TROOPS canvass(state)"
+type SpawnExpression struct {
+	Token token.Token // the 'RALLY_TROOPS' token
+	Call  *CallExpression
+}
+
+func (se *SpawnExpression) expressionNode()      {}
+func (se *SpawnExpression) TokenLiteral() string { return se.Token.Literal }
+func (se *SpawnExpression) String() string {
+	return "RALLY_TROOPS " + se.Call.String()
+}
+
 // InfixExpression represents an infix operator expression
 // e.g., "x + y" or "a == b"
 type InfixExpression struct {
@@ -140,3 +382,28 @@ func (ie *InfixExpression) String() string {
 
 	return out.String()
 }
+
+// AssignExpression represents a reassignment of an existing variable or of
+// an element of an array or hash
+// e.g., "x = 5", "counter += 1" or "deals[0] = 45"
+type AssignExpression struct {
+	Token    token.Token // The assignment operator token, e.g. = or +=
+	Target   Expression  // *Identifier or *IndexExpression
+	Operator string
+	Value    Expression
+}
+
+func (ae *AssignExpression) expressionNode()      {}
+func (ae *AssignExpression) TokenLiteral() string { return ae.Token.Literal }
+func (ae *AssignExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(ae.Target.String())
+	out.WriteString(" " + ae.Operator + " ")
+
+	if ae.Value != nil {
+		out.WriteString(ae.Value.String())
+	}
+
+	return out.String()
+}
@@ -0,0 +1,163 @@
+// file: internal/parser/ast_match.go
+// description: NEGOTIATE match expression and pattern AST nodes for the TRUMP programming language
+
+package parser
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/AndrewDonelson/trumplang/internal/lexer/token"
+)
+
+// Pattern is the interface for all patterns in a NEGOTIATE arm
+type Pattern interface {
+	Node
+	patternNode()
+}
+
+// MatchExpression represents a match on a value, usable as a statement or
+// as an expression that returns the value of the chosen arm
+// e.g., "NEGOTIATE (x) { 1, 2 => "small", 3..10 => "medium", _ => "YUGE" }"
+type MatchExpression struct {
+	Token   token.Token // the 'NEGOTIATE' token
+	Subject Expression
+	Arms    []*MatchArm
+}
+
+func (me *MatchExpression) expressionNode()      {}
+func (me *MatchExpression) TokenLiteral() string { return me.Token.Literal }
+func (me *MatchExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("NEGOTIATE (")
+	out.WriteString(me.Subject.String())
+	out.WriteString(") { ")
+	for _, arm := range me.Arms {
+		out.WriteString(arm.String())
+		out.WriteString(", ")
+	}
+	out.WriteString("}")
+
+	return out.String()
+}
+
+// MatchArm is a single arm of a NEGOTIATE. It is chosen when any of its
+// patterns matches and its guard, if any, holds. The body is either a block
+// or a single expression.
+type MatchArm struct {
+	Token    token.Token // The first token of the arm
+	Patterns []Pattern
+	Guard    Expression      // nil when there is no IF guard
+	Body     *BlockStatement // nil when the arm has an expression body
+	Value    Expression      // nil when the arm has a block body
+}
+
+func (ma *MatchArm) String() string {
+	var out bytes.Buffer
+
+	patterns := []string{}
+	for _, p := range ma.Patterns {
+		patterns = append(patterns, p.String())
+	}
+	out.WriteString(strings.Join(patterns, ", "))
+
+	if ma.Guard != nil {
+		out.WriteString(" IF ")
+		out.WriteString(ma.Guard.String())
+	}
+
+	out.WriteString(" => ")
+	if ma.Body != nil {
+		out.WriteString(ma.Body.String())
+	} else if ma.Value != nil {
+		out.WriteString(ma.Value.String())
+	}
+
+	return out.String()
+}
+
+// LiteralPattern matches values equal to an expression
+// e.g., "45", "\"deal\"" or "WINNING"
+type LiteralPattern struct {
+	Token token.Token // The first token of the value
+	Value Expression
+}
+
+func (lp *LiteralPattern) patternNode()         {}
+func (lp *LiteralPattern) TokenLiteral() string { return lp.Token.Literal }
+func (lp *LiteralPattern) String() string       { return lp.Value.String() }
+
+// RangePattern matches numbers between two bounds, including the upper one
+// unless the range is exclusive
+// e.g., "1..10" or "0..<100"
+type RangePattern struct {
+	Token     token.Token // The first token of the lower bound
+	Low       Expression
+	High      Expression
+	Exclusive bool
+}
+
+func (rp *RangePattern) patternNode()         {}
+func (rp *RangePattern) TokenLiteral() string { return rp.Token.Literal }
+func (rp *RangePattern) String() string {
+	operator := ".."
+	if rp.Exclusive {
+		operator = "..<"
+	}
+	return rp.Low.String() + operator + rp.High.String()
+}
+
+// ArrayPattern matches arrays element by element, optionally collecting
+// the remaining elements into Rest
+// e.g., "[first, _, ...others]"
+type ArrayPattern struct {
+	Token    token.Token // the '[' token
+	Elements []Pattern
+	Rest     *Identifier // nil when there is no ...rest
+}
+
+func (ap *ArrayPattern) patternNode()         {}
+func (ap *ArrayPattern) TokenLiteral() string { return ap.Token.Literal }
+func (ap *ArrayPattern) String() string {
+	elements := []string{}
+	for _, el := range ap.Elements {
+		elements = append(elements, el.String())
+	}
+	if ap.Rest != nil {
+		elements = append(elements, "..."+ap.Rest.String())
+	}
+	return "[" + strings.Join(elements, ", ") + "]"
+}
+
+// TypePattern matches values of a type
+// e.g., "INTEGER" or "STRING"
+type TypePattern struct {
+	Token token.Token
+	Name  string
+}
+
+func (tp *TypePattern) patternNode()         {}
+func (tp *TypePattern) TokenLiteral() string { return tp.Token.Literal }
+func (tp *TypePattern) String() string       { return tp.Name }
+
+// BindingPattern matches any value and binds it to a name in the arm
+// e.g., "deal"
+type BindingPattern struct {
+	Token token.Token // the token.IDENT token
+	Name  *Identifier
+}
+
+func (bp *BindingPattern) patternNode()         {}
+func (bp *BindingPattern) TokenLiteral() string { return bp.Token.Literal }
+func (bp *BindingPattern) String() string       { return bp.Name.String() }
+
+// WildcardPattern matches any value without binding it
+// e.g., "_"
+type WildcardPattern struct {
+	Token token.Token // the '_' token
+}
+
+func (wp *WildcardPattern) patternNode()         {}
+func (wp *WildcardPattern) TokenLiteral() string { return wp.Token.Literal }
+func (wp *WildcardPattern) String() string       { return "_" }
@@ -5,6 +5,7 @@ package parser
 
 import (
 	"bytes"
+	"fmt"
 
 	"github.com/AndrewDonelson/trumplang/internal/lexer/token"
 )
@@ -13,6 +14,7 @@ import (
 // e.g., "MAKE DEALS WHILE (x < 10) { ... }"
 type WhileStatement struct {
 	Token     token.Token // the 'MAKE' token
+	Label     string      // Optional loop label, empty if unlabeled
 	Condition Expression
 	Body      *BlockStatement
 }
@@ -22,6 +24,9 @@ func (ws *WhileStatement) TokenLiteral() string { return ws.Token.Literal }
 func (ws *WhileStatement) String() string {
 	var out bytes.Buffer
 
+	if ws.Label != "" {
+		out.WriteString(ws.Label + ": ")
+	}
 	out.WriteString("MAKE DEALS WHILE ")
 	out.WriteString("(")
 	out.WriteString(ws.Condition.String())
@@ -35,17 +40,55 @@ func (ws *WhileStatement) String() string {
 // e.g., "MAKE AMERICA GREAT AGAIN FOR (i=0; i<10; i++) { ... }"
 type ForStatement struct {
 	Token     token.Token // the 'MAKE' token
+	Label     string      // Optional loop label, empty if unlabeled
 	Init      Statement
 	Condition Expression
 	Update    Statement
 	Body      *BlockStatement
 }
 
+// ForEachStatement represents a loop over the elements of an array, the
+// characters of a string or the entries of a hash, with an optional index
+// or key binding
+// e.g., "MAKE AMERICA GREAT AGAIN FOR (i, deal IN deals) { ... }"
+type ForEachStatement struct {
+	Token    token.Token // the 'MAKE' token
+	Label    string      // Optional loop label, empty if unlabeled
+	Key      *Identifier // nil when only the value is bound
+	Value    *Identifier
+	Iterable Expression
+	Body     *BlockStatement
+}
+
+func (fe *ForEachStatement) statementNode()       {}
+func (fe *ForEachStatement) TokenLiteral() string { return fe.Token.Literal }
+func (fe *ForEachStatement) String() string {
+	var out bytes.Buffer
+
+	if fe.Label != "" {
+		out.WriteString(fe.Label + ": ")
+	}
+	out.WriteString("MAKE AMERICA GREAT AGAIN FOR (")
+	if fe.Key != nil {
+		out.WriteString(fe.Key.String() + ", ")
+	}
+	out.WriteString(fe.Value.String())
+	out.WriteString(" IN ")
+	out.WriteString(fe.Iterable.String())
+	out.WriteString(") ")
+	out.WriteString(fe.Body.String())
+
+	return out.String()
+}
+
 func (fs *ForStatement) statementNode()       {}
 func (fs *ForStatement) TokenLiteral() string { return fs.Token.Literal }
 func (fs *ForStatement) String() string {
 	var out bytes.Buffer
 
+	if fs.Label != "" {
+		out.WriteString(fs.Label + ": ")
+	}
 	out.WriteString("MAKE AMERICA GREAT AGAIN FOR ")
 	out.WriteString("(")
 	if fs.Init != nil {
@@ -67,6 +110,39 @@ func (fs *ForStatement) String() string {
 	return out.String()
 }
 
+// BreakStatement exits the innermost loop, or the loop with the given label
+// e.g., "YOU'RE FIRED;" or "YOU'RE FIRED outer;"
+type BreakStatement struct {
+	Token token.Token // the YOU'RE FIRED token
+	Label *Identifier // Optional loop label
+}
+
+func (bs *BreakStatement) statementNode()       {}
+func (bs *BreakStatement) TokenLiteral() string { return bs.Token.Literal }
+func (bs *BreakStatement) String() string {
+	if bs.Label != nil {
+		return bs.TokenLiteral() + " " + bs.Label.String() + ";"
+	}
+	return bs.TokenLiteral() + ";"
+}
+
+// ContinueStatement skips to the next iteration of the innermost loop, or of
+// the loop with the given label
+// e.g., "NEXT DEAL;" or "NEXT DEAL outer;"
+type ContinueStatement struct {
+	Token token.Token // the NEXT DEAL token
+	Label *Identifier // Optional loop label
+}
+
+func (cs *ContinueStatement) statementNode()       {}
+func (cs *ContinueStatement) TokenLiteral() string { return cs.Token.Literal }
+func (cs *ContinueStatement) String() string {
+	if cs.Label != nil {
+		return cs.TokenLiteral() + " " + cs.Label.String() + ";"
+	}
+	return cs.TokenLiteral() + ";"
+}
+
 // TweetStatement represents a print statement
 // e.g., "TWEET x;"
 type TweetStatement struct {
@@ -135,3 +211,113 @@ func (eos *ExecutiveOrderStatement) String() string {
 
 	return out.String()
 }
+
+// ThrowStatement raises an error that unwinds until caught
+// e.g., "FAKE_NEWS "Something went wrong";"
+type ThrowStatement struct {
+	Token token.Token // the 'FAKE_NEWS' token
+	Value Expression
+}
+
+func (ts *ThrowStatement) statementNode()       {}
+func (ts *ThrowStatement) TokenLiteral() string { return ts.Token.Literal }
+func (ts *ThrowStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(ts.TokenLiteral() + " ")
+
+	if ts.Value != nil {
+		out.WriteString(ts.Value.String())
+	}
+
+	out.WriteString(";")
+
+	return out.String()
+}
+
+// YieldStatement hands a value to whoever is reading a generator and
+// suspends the generator until the next value is asked for
+// e.g., "YIELD page;"
+type YieldStatement struct {
+	Token token.Token // the 'YIELD' token
+	Value Expression
+}
+
+func (ys *YieldStatement) statementNode()       {}
+func (ys *YieldStatement) TokenLiteral() string { return ys.Token.Literal }
+func (ys *YieldStatement) String() string {
+	return ys.TokenLiteral() + " " + ys.Value.String() + ";"
+}
+
+// TryStatement runs a block and handles any error it raises
+// e.g., "DENY { ... } BLAME (err) { ... } ANYWAY { ... }"
+type TryStatement struct {
+	Token      token.Token // the 'DENY' token
+	Body       *BlockStatement
+	CatchParam *Identifier     // Optional name bound to the caught error
+	Catch      *BlockStatement // Optional BLAME block
+	Finally    *BlockStatement // Optional ANYWAY block
+}
+
+func (ts *TryStatement) statementNode()       {}
+func (ts *TryStatement) TokenLiteral() string { return ts.Token.Literal }
+func (ts *TryStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("DENY ")
+	out.WriteString(ts.Body.String())
+
+	if ts.Catch != nil {
+		out.WriteString(" BLAME ")
+		if ts.CatchParam != nil {
+			out.WriteString("(" + ts.CatchParam.String() + ") ")
+		}
+		out.WriteString(ts.Catch.String())
+	}
+
+	if ts.Finally != nil {
+		out.WriteString(" ANYWAY ")
+		out.WriteString(ts.Finally.String())
+	}
+
+	return out.String()
+}
+
+// ImportStatement loads another file as a module
+// e.g., "IMPORT "utils.trump";" or "IMPORT "utils.trump" AS utils;"
+type ImportStatement struct {
+	Token token.Token // the 'IMPORT' token
+	Path  string      // Path relative to the importing file
+	Alias *Identifier // Optional name to bind the module to
+}
+
+func (is *ImportStatement) statementNode()       {}
+func (is *ImportStatement) TokenLiteral() string { return is.Token.Literal }
+func (is *ImportStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(is.TokenLiteral() + " ")
+	out.WriteString(fmt.Sprintf("%q", is.Path))
+
+	if is.Alias != nil {
+		out.WriteString(" AS " + is.Alias.String())
+	}
+
+	out.WriteString(";")
+
+	return out.String()
+}
+
+// ExportStatement marks a top-level declaration as visible to importers
+// e.g., "BORDER YUGE FUNCTION greet(name) { ... }"
+type ExportStatement struct {
+	Token       token.Token // the 'BORDER' token
+	Name        *Identifier // Name of the exported binding
+	Declaration Statement   // *LetStatement or *FunctionDeclaration
+}
+
+func (es *ExportStatement) statementNode()       {}
+func (es *ExportStatement) TokenLiteral() string { return es.Token.Literal }
+func (es *ExportStatement) String() string {
+	return es.TokenLiteral() + " " + es.Declaration.String()
+}
@@ -0,0 +1,165 @@
+// file: internal/parser/ast_walk.go
+// description: AST traversal for the TRUMP programming language
+
+package parser
+
+// Walk calls visit for node and, if visit returns true, walks each of the
+// node's children in source order
+func Walk(node Node, visit func(Node) bool) {
+	if node == nil || !visit(node) {
+		return
+	}
+
+	switch node := node.(type) {
+	case *Program:
+		walkStatements(node.Statements, visit)
+	case *BlockStatement:
+		walkStatements(node.Statements, visit)
+	case *LetStatement:
+		Walk(node.Name, visit)
+		Walk(node.Value, visit)
+	case *FunctionDeclaration:
+		Walk(node.Name, visit)
+		Walk(node.Function, visit)
+	case *ReturnStatement:
+		Walk(node.ReturnValue, visit)
+	case *ExpressionStatement:
+		Walk(node.Expression, visit)
+	case *IfStatement:
+		Walk(node.Condition, visit)
+		Walk(node.Consequence, visit)
+		if node.Alternative != nil {
+			Walk(node.Alternative, visit)
+		}
+	case *WhileStatement:
+		Walk(node.Condition, visit)
+		Walk(node.Body, visit)
+	case *ForStatement:
+		Walk(node.Init, visit)
+		Walk(node.Condition, visit)
+		Walk(node.Update, visit)
+		Walk(node.Body, visit)
+	case *ForEachStatement:
+		if node.Key != nil {
+			Walk(node.Key, visit)
+		}
+		Walk(node.Value, visit)
+		Walk(node.Iterable, visit)
+		Walk(node.Body, visit)
+	case *TweetStatement:
+		Walk(node.Value, visit)
+	case *RallyStatement:
+		Walk(node.Value, visit)
+	case *ExecutiveOrderStatement:
+		Walk(node.Value, visit)
+	case *ThrowStatement:
+		Walk(node.Value, visit)
+	case *YieldStatement:
+		Walk(node.Value, visit)
+	case *TryStatement:
+		Walk(node.Body, visit)
+		if node.CatchParam != nil {
+			Walk(node.CatchParam, visit)
+		}
+		if node.Catch != nil {
+			Walk(node.Catch, visit)
+		}
+		if node.Finally != nil {
+			Walk(node.Finally, visit)
+		}
+	case *ImportStatement:
+		if node.Alias != nil {
+			Walk(node.Alias, visit)
+		}
+	case *ExportStatement:
+		Walk(node.Declaration, visit)
+	case *IfExpression:
+		Walk(node.Condition, visit)
+		Walk(node.Consequence, visit)
+		if node.Alternative != nil {
+			Walk(node.Alternative, visit)
+		}
+	case *ConditionalExpression:
+		Walk(node.Condition, visit)
+		Walk(node.Consequence, visit)
+		Walk(node.Alternative, visit)
+	case *RangeExpression:
+		Walk(node.Start, visit)
+		Walk(node.End, visit)
+		Walk(node.Step, visit)
+	case *SpawnExpression:
+		Walk(node.Call, visit)
+	case *PrefixExpression:
+		Walk(node.Right, visit)
+	case *InfixExpression:
+		Walk(node.Left, visit)
+		Walk(node.Right, visit)
+	case *AssignExpression:
+		Walk(node.Target, visit)
+		Walk(node.Value, visit)
+	case *CallExpression:
+		Walk(node.Function, visit)
+		walkExpressions(node.Arguments, visit)
+	case *FunctionLiteral:
+		for _, param := range node.Parameters {
+			Walk(param, visit)
+		}
+		Walk(node.Body, visit)
+	case *ArrayLiteral:
+		walkExpressions(node.Elements, visit)
+	case *HashLiteral:
+		for _, pair := range node.Pairs {
+			Walk(pair.Key, visit)
+			Walk(pair.Value, visit)
+		}
+	case *IndexExpression:
+		Walk(node.Left, visit)
+		Walk(node.Index, visit)
+	case *SliceExpression:
+		Walk(node.Left, visit)
+		Walk(node.Start, visit)
+		Walk(node.End, visit)
+	case *InterpolatedString:
+		walkExpressions(node.Parts, visit)
+	case *Placeholder:
+		Walk(node.Value, visit)
+	case *MatchExpression:
+		Walk(node.Subject, visit)
+		for _, arm := range node.Arms {
+			for _, pattern := range arm.Patterns {
+				Walk(pattern, visit)
+			}
+			Walk(arm.Guard, visit)
+			if arm.Body != nil {
+				Walk(arm.Body, visit)
+			}
+			Walk(arm.Value, visit)
+		}
+	case *LiteralPattern:
+		Walk(node.Value, visit)
+	case *RangePattern:
+		Walk(node.Low, visit)
+		Walk(node.High, visit)
+	case *ArrayPattern:
+		for _, el := range node.Elements {
+			Walk(el, visit)
+		}
+		if node.Rest != nil {
+			Walk(node.Rest, visit)
+		}
+	case *BindingPattern:
+		Walk(node.Name, visit)
+	}
+}
+
+func walkStatements(statements []Statement, visit func(Node) bool) {
+	for _, statement := range statements {
+		Walk(statement, visit)
+	}
+}
+
+func walkExpressions(expressions []Expression, visit func(Node) bool) {
+	for _, expression := range expressions {
+		Walk(expression, visit)
+	}
+}
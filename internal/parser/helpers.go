@@ -12,6 +12,11 @@ import (
 
 // Report error for missing prefix parse function
 func (p *Parser) noPrefixParseFnError(t token.TokenType) {
+	// The lexer has already reported illegal input
+	if t == token.ILLEGAL {
+		return
+	}
+
 	msg := fmt.Sprintf("no prefix parse function for %s found", t)
 	p.addError(errors.UNEXPECTED_TOKEN, msg)
 }
@@ -70,9 +75,19 @@ func (p *Parser) curPrecedence() int {
 
 // Add a parsing error with code and message
 func (p *Parser) addError(code, msg string) {
-	line, column := p.curToken.Line, p.curToken.Column
-	err := errors.NewTrumpError(code, msg, line, column)
-	p.errors = append(p.errors, err)
+	p.addErrorAt(p.curToken, code, msg)
+}
+
+// Add an error reported at the given token
+func (p *Parser) addErrorAt(tok token.Token, code, msg string) {
+	err := errors.NewTrumpError(code, msg, tok.Line, tok.Column)
+	p.errors = append(p.errors, errors.InFile(tok.File, err))
+}
+
+// Add a warning reported at the given token
+func (p *Parser) addWarning(tok token.Token, msg string) {
+	warning := fmt.Sprintf("%s at %d:%d", msg, tok.Line, tok.Column)
+	p.warnings = append(p.warnings, errors.InFile(tok.File, warning))
 }
 
 // Skip comments and move to next non-comment token
@@ -81,3 +96,30 @@ func (p *Parser) skipComments() {
 		p.nextToken()
 	}
 }
+
+// Take the label waiting for the loop currently being parsed, if any
+func (p *Parser) takeLoopLabel() string {
+	label := p.loopLabel
+	p.loopLabel = ""
+	return label
+}
+
+// Push a loop as the innermost enclosing loop
+func (p *Parser) enterLoop(label string) {
+	p.loops = append(p.loops, label)
+}
+
+// Pop the innermost enclosing loop
+func (p *Parser) leaveLoop() {
+	p.loops = p.loops[:len(p.loops)-1]
+}
+
+// Check whether a loop with the given label encloses the current position
+func (p *Parser) inLoop(label string) bool {
+	for _, l := range p.loops {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
@@ -12,10 +12,20 @@ import (
 const (
 	_ int = iota
 	LOWEST
+	ASSIGN      // = or +=
+	CONDITIONAL // X ? Y : Z
+	LOGICAL_OR  // || or OR
+	LOGICAL_AND // && or AND
 	EQUALS      // ==
-	LESSGREATER // > or <
+	LESSGREATER // > or < or IN
+	RANGE       // 0..10
+	BIT_OR      // |
+	BIT_XOR     // ^
+	BIT_AND     // &
+	SHIFT       // << or >>
 	SUM         // +
-	PRODUCT     // *
+	PRODUCT     // * or / or %
+	POWER       // **
 	PREFIX      // -X or !X
 	CALL        // myFunction(X)
 	INDEX       // array[index]
@@ -23,30 +33,64 @@ const (
 
 // Map of token types to their precedence
 var precedences = map[token.TokenType]int{
-	token.EQ:       EQUALS,
-	token.NOT_EQ:   EQUALS,
-	token.LT:       LESSGREATER,
-	token.GT:       LESSGREATER,
-	token.LT_EQ:    LESSGREATER,
-	token.GT_EQ:    LESSGREATER,
-	token.PLUS:     SUM,
-	token.MINUS:    SUM,
-	token.SLASH:    PRODUCT,
-	token.ASTERISK: PRODUCT,
-	token.LPAREN:   CALL,
-	token.LBRACKET: INDEX,
+	token.ASSIGN:          ASSIGN,
+	token.PLUS_ASSIGN:     ASSIGN,
+	token.MINUS_ASSIGN:    ASSIGN,
+	token.ASTERISK_ASSIGN: ASSIGN,
+	token.SLASH_ASSIGN:    ASSIGN,
+	token.QUESTION:        CONDITIONAL,
+	token.OR:              LOGICAL_OR,
+	token.AND:             LOGICAL_AND,
+	token.EQ:              EQUALS,
+	token.NOT_EQ:          EQUALS,
+	token.LT:              LESSGREATER,
+	token.GT:              LESSGREATER,
+	token.LT_EQ:           LESSGREATER,
+	token.GT_EQ:           LESSGREATER,
+	token.IN:              LESSGREATER,
+	token.DOTDOT:          RANGE,
+	token.DOTDOT_LT:       RANGE,
+	token.PLUS:            SUM,
+	token.MINUS:           SUM,
+	token.SLASH:           PRODUCT,
+	token.ASTERISK:        PRODUCT,
+	token.PERCENT:         PRODUCT,
+	token.POWER:           POWER,
+	token.BIT_OR:          BIT_OR,
+	token.BIT_XOR:         BIT_XOR,
+	token.BIT_AND:         BIT_AND,
+	token.SHIFT_LEFT:      SHIFT,
+	token.SHIFT_RIGHT:     SHIFT,
+	token.LPAREN:          CALL,
+	token.LBRACKET:        INDEX,
 }
 
 // Parser for the TRUMP language
 type Parser struct {
-	l      *lexer.Lexer
-	errors []string
+	l        *lexer.Lexer
+	errors   []string
+	warnings []string
 
 	curToken  token.Token
 	peekToken token.Token
 
 	prefixParseFns map[token.TokenType]prefixParseFn
 	infixParseFns  map[token.TokenType]infixParseFn
+
+	// Labels of the loops enclosing the current position ("" if unlabeled),
+	// used to reject YOU'RE FIRED and NEXT DEAL outside of a loop
+	loops []string
+	// Label waiting to be attached to the loop about to be parsed
+	loopLabel string
+	// Number of enclosing blocks, zero at the top level of the file
+	blockDepth int
+	// Whether the file opened with a BIGLY pragma
+	bigly bool
+	// Function whose body is being parsed, nil at the top level of the file
+	function *FunctionLiteral
+	// Set while parsing NEGOTIATE patterns and guards, where "(x) =>" ends
+	// the pattern rather than starting a lambda
+	noLambda bool
 }
 
 type prefixParseFn func() Expression
@@ -68,25 +112,50 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerPrefix(token.INT, p.parseIntegerLiteral)
 	p.registerPrefix(token.FLOAT, p.parseFloatLiteral)
 	p.registerPrefix(token.STRING, p.parseStringLiteral)
+	p.registerPrefix(token.STRING_START, p.parseInterpolatedString)
 	p.registerPrefix(token.BANG, p.parsePrefixExpression)
 	p.registerPrefix(token.MINUS, p.parsePrefixExpression)
+	p.registerPrefix(token.NOT, p.parsePrefixExpression)
+	p.registerPrefix(token.TILDE, p.parsePrefixExpression)
 	p.registerPrefix(token.LPAREN, p.parseGroupedExpression)
 	p.registerPrefix(token.LBRACKET, p.parseArrayLiteral)
+	p.registerPrefix(token.LBRACE, p.parseHashLiteral)
 	p.registerPrefix(token.FUNCTION, p.parseFunctionLiteral)
 	p.registerPrefix(token.WINNING, p.parseBoolean)
 	p.registerPrefix(token.LOSER, p.parseBoolean)
+	p.registerPrefix(token.NEGOTIATE, p.parseMatchExpression)
+	p.registerPrefix(token.RALLY_TROOPS, p.parseSpawnExpression)
+	p.registerPrefix(token.BUILD, p.parseIfExpression)
 
 	// Register infix parse functions
 	p.registerInfix(token.PLUS, p.parseInfixExpression)
 	p.registerInfix(token.MINUS, p.parseInfixExpression)
 	p.registerInfix(token.SLASH, p.parseInfixExpression)
 	p.registerInfix(token.ASTERISK, p.parseInfixExpression)
+	p.registerInfix(token.PERCENT, p.parseInfixExpression)
+	p.registerInfix(token.POWER, p.parseInfixExpression)
+	p.registerInfix(token.BIT_AND, p.parseInfixExpression)
+	p.registerInfix(token.BIT_OR, p.parseInfixExpression)
+	p.registerInfix(token.BIT_XOR, p.parseInfixExpression)
+	p.registerInfix(token.SHIFT_LEFT, p.parseInfixExpression)
+	p.registerInfix(token.SHIFT_RIGHT, p.parseInfixExpression)
+	p.registerInfix(token.AND, p.parseInfixExpression)
+	p.registerInfix(token.OR, p.parseInfixExpression)
 	p.registerInfix(token.EQ, p.parseInfixExpression)
 	p.registerInfix(token.NOT_EQ, p.parseInfixExpression)
 	p.registerInfix(token.LT, p.parseInfixExpression)
 	p.registerInfix(token.GT, p.parseInfixExpression)
 	p.registerInfix(token.LT_EQ, p.parseInfixExpression)
 	p.registerInfix(token.GT_EQ, p.parseInfixExpression)
+	p.registerInfix(token.IN, p.parseInfixExpression)
+	p.registerInfix(token.DOTDOT, p.parseRangeExpression)
+	p.registerInfix(token.DOTDOT_LT, p.parseRangeExpression)
+	p.registerInfix(token.ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.PLUS_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.MINUS_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.ASTERISK_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.SLASH_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.QUESTION, p.parseConditionalExpression)
 	p.registerInfix(token.LPAREN, p.parseCallExpression)
 	p.registerInfix(token.LBRACKET, p.parseIndexExpression)
 
@@ -109,6 +178,9 @@ func (p *Parser) Parse() *Program {
 		Statements: []Statement{},
 	}
 
+	p.skipComments()
+	program.Bigly = p.parseBiglyPragma()
+
 	for !p.curTokenIs(token.EOF) {
 		// Skip comments before parsing statements
 		p.skipComments()
@@ -124,10 +196,22 @@ func (p *Parser) Parse() *Program {
 		p.nextToken()
 	}
 
+	// Declarations are only checked in programs that parsed cleanly
+	if len(p.Errors()) == 0 {
+		p.resolve(program)
+	}
+
 	return program
 }
 
-// Errors returns the list of errors encountered during parsing
+// Errors returns the list of errors encountered during lexing and parsing
 func (p *Parser) Errors() []string {
-	return p.errors
+	errs := append([]string{}, p.l.Errors()...)
+	return append(errs, p.errors...)
+}
+
+// Warnings returns the list of warnings for code that parsed but is
+// probably wrong
+func (p *Parser) Warnings() []string {
+	return p.warnings
 }
@@ -5,15 +5,17 @@ package parser
 
 import (
 	"fmt"
+	"math/big"
 	"strconv"
+	"strings"
 
 	"github.com/AndrewDonelson/trumplang/internal/errors"
 	"github.com/AndrewDonelson/trumplang/internal/lexer/token"
 )
 
-// Parse a for statement
-func (p *Parser) parseForStatement() *ForStatement {
-	stmt := &ForStatement{Token: p.curToken}
+// Parse a for statement, either C-style or a for-each loop
+func (p *Parser) parseForStatement() Statement {
+	stmt := &ForStatement{Token: p.curToken, Label: p.takeLoopLabel()}
 
 	// Expect MAKE AMERICA GREAT AGAIN FOR
 	if !p.expectPeek(token.AMERICA) {
@@ -44,10 +46,16 @@ func (p *Parser) parseForStatement() *ForStatement {
 
 	p.nextToken()
 
+	// A for-each loop names its bindings before IN, e.g. (deal IN deals)
+	if p.curTokenIs(token.IDENT) && (p.peekTokenIs(token.IN) || p.peekTokenIs(token.COMMA)) {
+		return p.parseForEachStatement(stmt.Token, stmt.Label)
+	}
+
 	// Parse initialization
 	stmt.Init = p.parseStatement()
 
-	if !p.expectPeek(token.SEMICOLON) {
+	// Declarations consume their own optional semicolon
+	if !p.curTokenIs(token.SEMICOLON) && !p.expectPeek(token.SEMICOLON) {
 		p.addError(errors.UNEXPECTED_TOKEN, "Expected ';' after initialization")
 		return nil
 	}
@@ -78,7 +86,51 @@ func (p *Parser) parseForStatement() *ForStatement {
 		return nil
 	}
 
+	p.enterLoop(stmt.Label)
+	stmt.Body = p.parseBlockStatement()
+	p.leaveLoop()
+
+	return stmt
+}
+
+// Parse the rest of a for-each loop from its first binding, e.g.
+// "(deal IN deals)" or "(i, deal IN deals)"
+func (p *Parser) parseForEachStatement(tok token.Token, label string) Statement {
+	stmt := &ForEachStatement{Token: tok, Label: label}
+	stmt.Value = &Identifier{Token: p.curToken, Value: p.curToken.Literal}
+
+	// With two bindings the first is the index or key
+	if p.peekTokenIs(token.COMMA) {
+		p.nextToken()
+		if !p.expectPeek(token.IDENT) {
+			p.addError(errors.EXPECTED_IDENTIFIER, "Expected a name after ',' in for-each loop")
+			return nil
+		}
+		stmt.Key = stmt.Value
+		stmt.Value = &Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	}
+
+	if !p.expectPeek(token.IN) {
+		p.addError(errors.UNEXPECTED_TOKEN, "Expected IN in for-each loop")
+		return nil
+	}
+
+	p.nextToken()
+	stmt.Iterable = p.parseExpression(LOWEST)
+
+	if !p.expectPeek(token.RPAREN) {
+		p.addError(errors.UNEXPECTED_TOKEN, "Expected ')' after for-each loop declaration")
+		return nil
+	}
+
+	if !p.expectPeek(token.LBRACE) {
+		p.addError(errors.UNEXPECTED_TOKEN, "Expected '{' after for-each loop declaration")
+		return nil
+	}
+
+	p.enterLoop(stmt.Label)
 	stmt.Body = p.parseBlockStatement()
+	p.leaveLoop()
 
 	return stmt
 }
@@ -90,6 +142,9 @@ func (p *Parser) parseBlockStatement() *BlockStatement {
 		Statements: []Statement{},
 	}
 
+	p.blockDepth++
+	defer func() { p.blockDepth-- }()
+
 	p.nextToken()
 
 	for !p.curTokenIs(token.RBRACE) && !p.curTokenIs(token.EOF) {
@@ -162,12 +217,22 @@ func (p *Parser) parseIdentifier() Expression {
 
 // Parse an integer literal
 func (p *Parser) parseIntegerLiteral() Expression {
+	if p.peekTokenIs(token.BILLIONS) {
+		return p.parseDecimalLiteral()
+	}
+
 	lit := &IntegerLiteral{Token: p.curToken}
 
-	value, err := strconv.ParseInt(p.curToken.Literal, 0, 64)
+	// The lexer has already checked the digits, so only the range can fail
+	digits, base := integerDigits(p.curToken.Literal)
+	value, err := strconv.ParseInt(digits, base, 64)
 	if err != nil {
-		msg := fmt.Sprintf("could not parse %q as integer", p.curToken.Literal)
-		p.addError(errors.UNEXPECTED_TOKEN, msg)
+		if p.bigly {
+			bigValue, _ := new(big.Int).SetString(digits, base)
+			return &BigIntegerLiteral{Token: p.curToken, Value: bigValue}
+		}
+		msg := fmt.Sprintf("integer literal %s does not fit in 64 bits (start the file with BIGLY; for arbitrary precision)", p.curToken.Literal)
+		p.addError(errors.INTEGER_OVERFLOW, msg)
 		return nil
 	}
 
@@ -176,14 +241,37 @@ func (p *Parser) parseIntegerLiteral() Expression {
 	return lit
 }
 
+// Split an integer literal into its digits, without separators or a base
+// prefix, and its base. Unprefixed literals are always decimal, so 017 is 17.
+func integerDigits(literal string) (string, int) {
+	digits := strings.ReplaceAll(literal, "_", "")
+	if len(digits) > 2 && digits[0] == '0' {
+		switch digits[1] {
+		case 'x', 'X':
+			return digits[2:], 16
+		case 'o', 'O':
+			return digits[2:], 8
+		case 'b', 'B':
+			return digits[2:], 2
+		}
+	}
+	return digits, 10
+}
+
 // Parse a float literal
 func (p *Parser) parseFloatLiteral() Expression {
+	if p.peekTokenIs(token.BILLIONS) {
+		return p.parseDecimalLiteral()
+	}
+
 	lit := &FloatLiteral{Token: p.curToken}
 
-	value, err := strconv.ParseFloat(p.curToken.Literal, 64)
+	// The lexer has already checked the digits, so this fails when the
+	// value is too large for a FLOAT
+	value, err := strconv.ParseFloat(strings.ReplaceAll(p.curToken.Literal, "_", ""), 64)
 	if err != nil {
-		msg := fmt.Sprintf("could not parse %q as float", p.curToken.Literal)
-		p.addError(errors.UNEXPECTED_TOKEN, msg)
+		msg := fmt.Sprintf("float literal %s is too large for a FLOAT (use BILLIONS for exact decimals of any size)", p.curToken.Literal)
+		p.addError(errors.INVALID_NUMBER, msg)
 		return nil
 	}
 
@@ -192,6 +280,21 @@ func (p *Parser) parseFloatLiteral() Expression {
 	return lit
 }
 
+// Parse a number followed by BILLIONS as an exact decimal, e.g. 12.50 BILLIONS
+func (p *Parser) parseDecimalLiteral() Expression {
+	lit := &DecimalLiteral{Token: p.curToken, Value: p.curToken.Literal}
+
+	if strings.HasPrefix(lit.Value, "0x") || strings.HasPrefix(lit.Value, "0X") ||
+		strings.HasPrefix(lit.Value, "0o") || strings.HasPrefix(lit.Value, "0O") ||
+		strings.HasPrefix(lit.Value, "0b") || strings.HasPrefix(lit.Value, "0B") {
+		p.addError(errors.SYNTAX_ERROR, "BILLIONS literals must be decimal")
+		return nil
+	}
+
+	p.nextToken() // Consume BILLIONS
+	return lit
+}
+
 // Parse a string literal
 func (p *Parser) parseStringLiteral() Expression {
 	return &StringLiteral{
@@ -200,6 +303,34 @@ func (p *Parser) parseStringLiteral() Expression {
 	}
 }
 
+// Parse an interpolated string, e.g. "Hello ${name}!"
+func (p *Parser) parseInterpolatedString() Expression {
+	str := &InterpolatedString{Token: p.curToken}
+	str.Parts = append(str.Parts, &StringLiteral{Token: p.curToken, Value: p.curToken.Literal})
+
+	for !p.curTokenIs(token.STRING_END) {
+		p.nextToken()
+		if p.curTokenIs(token.STRING_MID) || p.curTokenIs(token.STRING_END) {
+			p.addError(errors.UNEXPECTED_TOKEN, "Empty string interpolation")
+			return nil
+		}
+
+		placeholder := &Placeholder{Token: p.curToken}
+		placeholder.Value = p.parseExpression(LOWEST)
+		str.Parts = append(str.Parts, placeholder)
+
+		if !p.peekTokenIs(token.STRING_MID) && !p.peekTokenIs(token.STRING_END) {
+			p.addError(errors.UNEXPECTED_TOKEN, "Expected '}' to close string interpolation")
+			return nil
+		}
+
+		p.nextToken()
+		str.Parts = append(str.Parts, &StringLiteral{Token: p.curToken, Value: p.curToken.Literal})
+	}
+
+	return str
+}
+
 // Parse a boolean literal
 func (p *Parser) parseBoolean() Expression {
 	return &BooleanLiteral{
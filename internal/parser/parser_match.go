@@ -0,0 +1,242 @@
+// file: internal/parser/parser_match.go
+// description: NEGOTIATE match expression and pattern parsing for the TRUMP programming language
+
+package parser
+
+import (
+	"github.com/AndrewDonelson/trumplang/internal/errors"
+	"github.com/AndrewDonelson/trumplang/internal/lexer/token"
+)
+
+// Type names usable as patterns and type annotations, matching the
+// interpreter's object types
+var typeNames = map[string]bool{
+	"INTEGER":     true,
+	"BIG_INTEGER": true,
+	"FLOAT":       true,
+	"STRING":      true,
+	"BOOLEAN":     true,
+	"ARRAY":       true,
+	"HASH":        true,
+	"FUNCTION":    true,
+	"NULL":        true,
+	"MODULE":      true,
+	"RANGE":       true,
+	"GENERATOR":   true,
+	"TASK":        true,
+	"CHANNEL":     true,
+}
+
+// Parse a NEGOTIATE match expression
+func (p *Parser) parseMatchExpression() Expression {
+	match := &MatchExpression{Token: p.curToken}
+
+	if !p.expectPeek(token.LPAREN) {
+		p.addError(errors.UNEXPECTED_TOKEN, "Expected '(' after NEGOTIATE")
+		return nil
+	}
+
+	p.nextToken()
+	match.Subject = p.parseExpression(LOWEST)
+
+	if !p.expectPeek(token.RPAREN) {
+		p.addError(errors.UNEXPECTED_TOKEN, "Expected ')' after NEGOTIATE value")
+		return nil
+	}
+
+	if !p.expectPeek(token.LBRACE) {
+		p.addError(errors.UNEXPECTED_TOKEN, "Expected '{' after NEGOTIATE value")
+		return nil
+	}
+
+	p.nextToken()
+
+	for !p.curTokenIs(token.RBRACE) && !p.curTokenIs(token.EOF) {
+		// Skip comments between arms
+		if p.curTokenIs(token.COMMENT) {
+			p.nextToken()
+			continue
+		}
+
+		arm := p.parseMatchArm()
+		if arm == nil {
+			return nil
+		}
+		match.Arms = append(match.Arms, arm)
+
+		// Arms may be separated by commas or semicolons
+		p.nextToken()
+		if p.curTokenIs(token.COMMA) || p.curTokenIs(token.SEMICOLON) {
+			p.nextToken()
+		}
+	}
+
+	if !p.curTokenIs(token.RBRACE) {
+		p.addError(errors.UNEXPECTED_TOKEN, "Expected '}' to close NEGOTIATE")
+		return nil
+	}
+
+	p.checkBooleanCoverage(match)
+
+	return match
+}
+
+// Parse one arm of a NEGOTIATE, e.g. "1, 2 IF big => "deal""
+func (p *Parser) parseMatchArm() *MatchArm {
+	arm := &MatchArm{Token: p.curToken}
+
+	// The "=>" after a parenthesized pattern or guard ends the arm's head
+	outerNoLambda := p.noLambda
+	p.noLambda = true
+	defer func() { p.noLambda = outerNoLambda }()
+
+	for {
+		pattern := p.parsePattern()
+		if pattern == nil {
+			return nil
+		}
+		arm.Patterns = append(arm.Patterns, pattern)
+
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+		p.nextToken()
+		p.nextToken()
+	}
+
+	// Parse the optional guard
+	if p.peekTokenIs(token.IF) {
+		p.nextToken()
+		p.nextToken()
+		arm.Guard = p.parseExpression(LOWEST)
+	}
+
+	if !p.expectPeek(token.ARROW) {
+		p.addError(errors.UNEXPECTED_TOKEN, "Expected '=>' after NEGOTIATE pattern")
+		return nil
+	}
+
+	p.noLambda = false
+
+	p.nextToken()
+	if p.curTokenIs(token.LBRACE) {
+		arm.Body = p.parseBlockStatement()
+	} else {
+		arm.Value = p.parseExpression(LOWEST)
+	}
+
+	return arm
+}
+
+// Parse a single pattern
+func (p *Parser) parsePattern() Pattern {
+	switch {
+	case p.curTokenIs(token.IDENT) && p.curToken.Literal == "_":
+		return &WildcardPattern{Token: p.curToken}
+	case p.curTokenIs(token.IDENT) && typeNames[p.curToken.Literal]:
+		return &TypePattern{Token: p.curToken, Name: p.curToken.Literal}
+	case p.curTokenIs(token.BILLIONS):
+		return &TypePattern{Token: p.curToken, Name: p.curToken.Literal}
+	case p.curTokenIs(token.IDENT):
+		return &BindingPattern{Token: p.curToken, Name: &Identifier{Token: p.curToken, Value: p.curToken.Literal}}
+	case p.curTokenIs(token.LBRACKET):
+		return p.parseArrayPattern()
+	}
+
+	tok := p.curToken
+	value := p.parseExpression(LOWEST)
+	if value == nil {
+		return nil
+	}
+
+	rng, ok := value.(*RangeExpression)
+	if !ok {
+		return &LiteralPattern{Token: tok, Value: value}
+	}
+	if rng.Step != nil {
+		p.addErrorAt(rng.Token, errors.SYNTAX_ERROR, "A range pattern cannot have a step")
+		return nil
+	}
+
+	return &RangePattern{Token: tok, Low: rng.Start, High: rng.End, Exclusive: rng.Exclusive}
+}
+
+// Parse an array destructuring pattern, e.g. "[first, ...rest]"
+func (p *Parser) parseArrayPattern() Pattern {
+	pattern := &ArrayPattern{Token: p.curToken}
+
+	if p.peekTokenIs(token.RBRACKET) {
+		p.nextToken()
+		return pattern
+	}
+
+	for {
+		p.nextToken()
+
+		// A ...rest binding must come last
+		if p.curTokenIs(token.ELLIPSIS) {
+			if !p.expectPeek(token.IDENT) {
+				p.addError(errors.EXPECTED_IDENTIFIER, "Expected a name after '...'")
+				return nil
+			}
+			pattern.Rest = &Identifier{Token: p.curToken, Value: p.curToken.Literal}
+			break
+		}
+
+		element := p.parsePattern()
+		if element == nil {
+			return nil
+		}
+		pattern.Elements = append(pattern.Elements, element)
+
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+		p.nextToken()
+	}
+
+	if !p.expectPeek(token.RBRACKET) {
+		p.addError(errors.UNEXPECTED_TOKEN, "Expected ']' to close array pattern")
+		return nil
+	}
+
+	return pattern
+}
+
+// Warn when a NEGOTIATE on booleans leaves WINNING or LOSER unhandled.
+// Guarded arms never count as covering a value.
+func (p *Parser) checkBooleanCoverage(match *MatchExpression) {
+	onBooleans, winning, loser := false, false, false
+
+	for _, arm := range match.Arms {
+		for _, pattern := range arm.Patterns {
+			switch pattern := pattern.(type) {
+			case *LiteralPattern:
+				b, ok := pattern.Value.(*BooleanLiteral)
+				if !ok {
+					continue
+				}
+				onBooleans = true
+				if arm.Guard == nil {
+					winning = winning || b.Value
+					loser = loser || !b.Value
+				}
+			case *WildcardPattern, *BindingPattern:
+				if arm.Guard == nil {
+					return
+				}
+			case *TypePattern:
+				if pattern.Name == "BOOLEAN" && arm.Guard == nil {
+					return
+				}
+			}
+		}
+	}
+
+	if onBooleans && !winning {
+		p.addWarning(match.Token, "NEGOTIATE on booleans never handles WINNING")
+	}
+	if onBooleans && !loser {
+		p.addWarning(match.Token, "NEGOTIATE on booleans never handles LOSER")
+	}
+}
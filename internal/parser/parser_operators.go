@@ -17,6 +17,11 @@ func (p *Parser) parsePrefixExpression() Expression {
 		Operator: p.curToken.Literal,
 	}
 
+	// NOT is the word form of !
+	if p.curTokenIs(token.NOT) {
+		expression.Operator = "!"
+	}
+
 	p.nextToken()
 
 	expression.Right = p.parseExpression(PREFIX)
@@ -24,6 +29,22 @@ func (p *Parser) parsePrefixExpression() Expression {
 	return expression
 }
 
+// Parse a RALLY_TROOPS expression, which must be a function call
+func (p *Parser) parseSpawnExpression() Expression {
+	expression := &SpawnExpression{Token: p.curToken}
+
+	p.nextToken()
+
+	call, ok := p.parseExpression(PREFIX).(*CallExpression)
+	if !ok {
+		p.addErrorAt(expression.Token, errors.SYNTAX_ERROR, "RALLY_TROOPS must be followed by a function call")
+		return nil
+	}
+	expression.Call = call
+
+	return expression
+}
+
 // Parse an infix expression
 func (p *Parser) parseInfixExpression(left Expression) Expression {
 	expression := &InfixExpression{
@@ -32,27 +53,190 @@ func (p *Parser) parseInfixExpression(left Expression) Expression {
 		Left:     left,
 	}
 
+	// AND and OR are the word forms of && and ||
+	if p.curTokenIs(token.AND) || p.curTokenIs(token.OR) {
+		expression.Operator = string(p.curToken.Type)
+	}
+
 	precedence := p.curPrecedence()
+
+	// Exponentiation is right-associative: 2 ** 3 ** 2 == 2 ** (3 ** 2)
+	if p.curTokenIs(token.POWER) {
+		precedence--
+	}
+
 	p.nextToken()
 	expression.Right = p.parseExpression(precedence)
 
 	return expression
 }
 
-// Parse a grouped expression
+// Parse an assignment expression (right-associative, so "a = b = 1" assigns both)
+func (p *Parser) parseAssignExpression(left Expression) Expression {
+	expression := &AssignExpression{
+		Token:    p.curToken,
+		Operator: p.curToken.Literal,
+	}
+
+	switch left.(type) {
+	case *Identifier, *IndexExpression:
+		expression.Target = left
+	default:
+		p.addError(errors.SYNTAX_ERROR, fmt.Sprintf("cannot assign to %s", left.String()))
+		return nil
+	}
+
+	p.nextToken()
+	expression.Value = p.parseExpression(ASSIGN - 1)
+
+	return expression
+}
+
+// Parse a conditional expression once its condition has been read. It is
+// right-associative, so "a ? b : c ? d : e" chooses between b and "c ? d : e".
+func (p *Parser) parseConditionalExpression(condition Expression) Expression {
+	expression := &ConditionalExpression{
+		Token:     p.curToken,
+		Condition: condition,
+	}
+
+	p.nextToken()
+	expression.Consequence = p.parseExpression(LOWEST)
+
+	if !p.expectPeek(token.COLON) {
+		p.addError(errors.UNEXPECTED_TOKEN, "Expected ':' in conditional expression")
+		return nil
+	}
+
+	p.nextToken()
+	expression.Alternative = p.parseExpression(CONDITIONAL - 1)
+
+	return expression
+}
+
+// Parse a range expression, e.g. "0..10", "0..<10" or "10..0 BY -2"
+func (p *Parser) parseRangeExpression(start Expression) Expression {
+	expression := &RangeExpression{
+		Token:     p.curToken,
+		Start:     start,
+		Exclusive: p.curTokenIs(token.DOTDOT_LT),
+	}
+
+	p.nextToken()
+	expression.End = p.parseExpression(RANGE)
+	if expression.End == nil {
+		return nil
+	}
+
+	if p.peekTokenIs(token.BY) {
+		p.nextToken()
+		p.nextToken()
+		expression.Step = p.parseExpression(RANGE)
+		if expression.Step == nil {
+			return nil
+		}
+	}
+
+	return expression
+}
+
+// Parse a grouped expression, or a lambda if the parentheses hold its
+// parameters and are followed by "=>"
 func (p *Parser) parseGroupedExpression() Expression {
+	paren := p.curToken
+
+	// Empty parentheses can only start a lambda
+	if p.peekTokenIs(token.RPAREN) {
+		p.nextToken()
+		return p.parseLambda(paren, []*Identifier{}, []string{})
+	}
+
 	p.nextToken()
 
 	exp := p.parseExpression(LOWEST)
 
+	// A comma or type annotation after a name means a parameter list
+	if ident, ok := exp.(*Identifier); ok && (p.peekTokenIs(token.COMMA) || p.peekTokenIs(token.COLON)) {
+		return p.parseLambdaParameters(paren, ident)
+	}
+
 	if !p.expectPeek(token.RPAREN) {
 		p.addError(errors.UNEXPECTED_TOKEN, "Expected ')'")
 		return nil
 	}
 
+	if ident, ok := exp.(*Identifier); ok && p.peekTokenIs(token.ARROW) && !p.noLambda {
+		return p.parseLambda(paren, []*Identifier{ident}, []string{""})
+	}
+
 	return exp
 }
 
+// Parse the rest of a lambda's parameter list once its first name has been
+// read, e.g. "(x: INTEGER, y) => x + y"
+func (p *Parser) parseLambdaParameters(paren token.Token, first *Identifier) Expression {
+	params := []*Identifier{first}
+	types := []string{}
+
+	for {
+		typeName := ""
+		if p.peekTokenIs(token.COLON) {
+			var ok bool
+			if typeName, ok = p.parseTypeAnnotation(); !ok {
+				return nil
+			}
+		}
+		types = append(types, typeName)
+
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+		p.nextToken()
+
+		if !p.expectPeek(token.IDENT) {
+			p.addError(errors.EXPECTED_IDENTIFIER, "Expected a parameter name in lambda")
+			return nil
+		}
+		params = append(params, &Identifier{Token: p.curToken, Value: p.curToken.Literal})
+	}
+
+	if !p.expectPeek(token.RPAREN) {
+		p.addError(errors.UNEXPECTED_TOKEN, "Expected ')' after lambda parameters")
+		return nil
+	}
+
+	return p.parseLambda(paren, params, types)
+}
+
+// Parse the "=>" and body of a lambda. An expression body is stored as a
+// block returning its value, so lambdas run like any other function.
+func (p *Parser) parseLambda(paren token.Token, params []*Identifier, types []string) Expression {
+	if !p.expectPeek(token.ARROW) {
+		p.addError(errors.UNEXPECTED_TOKEN, "Expected '=>' after lambda parameters")
+		return nil
+	}
+
+	lit := &FunctionLiteral{Token: paren, Parameters: params, ParameterTypes: types, Lambda: true}
+
+	// Loops outside the lambda cannot be controlled from inside it
+	outerLoops, outerNoLambda, outerFunction := p.loops, p.noLambda, p.function
+	p.loops, p.noLambda, p.function = nil, false, lit
+
+	if p.peekTokenIs(token.LBRACE) {
+		p.nextToken()
+		lit.Body = p.parseBlockStatement()
+	} else {
+		arrow := p.curToken
+		p.nextToken()
+		value := p.parseExpression(LOWEST)
+		lit.Body = &BlockStatement{Token: arrow, Statements: []Statement{&ReturnStatement{Token: arrow, ReturnValue: value}}}
+	}
+
+	p.loops, p.noLambda, p.function = outerLoops, outerNoLambda, outerFunction
+
+	return lit
+}
+
 // Parse an array literal
 func (p *Parser) parseArrayLiteral() Expression {
 	array := &ArrayLiteral{Token: p.curToken}
@@ -62,10 +246,53 @@ func (p *Parser) parseArrayLiteral() Expression {
 	return array
 }
 
+// Parse a hash literal
+func (p *Parser) parseHashLiteral() Expression {
+	hash := &HashLiteral{Token: p.curToken}
+
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken()
+
+		// Handle comments between pairs
+		if p.curTokenIs(token.COMMENT) {
+			continue
+		}
+
+		key := p.parseExpression(LOWEST)
+
+		if !p.expectPeek(token.COLON) {
+			p.addError(errors.UNEXPECTED_TOKEN, "Expected ':' after hash key")
+			return nil
+		}
+
+		p.nextToken()
+		value := p.parseExpression(LOWEST)
+
+		hash.Pairs = append(hash.Pairs, HashPair{Key: key, Value: value})
+
+		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
+			p.addError(errors.UNEXPECTED_TOKEN, "Expected ',' or '}' after hash value")
+			return nil
+		}
+	}
+
+	if !p.expectPeek(token.RBRACE) {
+		p.addError(errors.UNEXPECTED_TOKEN, "Expected '}'")
+		return nil
+	}
+
+	return hash
+}
+
 // Parse a list of expressions
 func (p *Parser) parseExpressionList(end token.TokenType) []Expression {
 	list := []Expression{}
 
+	// Lambdas are fine as arguments, even inside a NEGOTIATE guard
+	outerNoLambda := p.noLambda
+	p.noLambda = false
+	defer func() { p.noLambda = outerNoLambda }()
+
 	if p.peekTokenIs(end) {
 		p.nextToken()
 		return list
@@ -114,12 +341,33 @@ func (p *Parser) parseExpressionList(end token.TokenType) []Expression {
 func (p *Parser) parseFunctionLiteral() Expression {
 	lit := &FunctionLiteral{Token: p.curToken}
 
+	if !p.parseFunctionSignature(lit) {
+		return nil
+	}
+
+	return lit
+}
+
+// Parse the parameters, optional rating and body of a function literal
+func (p *Parser) parseFunctionSignature(lit *FunctionLiteral) bool {
 	if !p.expectPeek(token.LPAREN) {
 		p.addError(errors.UNEXPECTED_TOKEN, "Expected '(' after FUNCTION")
-		return nil
+		return false
 	}
 
-	lit.Parameters = p.parseFunctionParameters()
+	lit.Parameters, lit.ParameterTypes = p.parseFunctionParameters()
+	if lit.Parameters == nil {
+		return false
+	}
+
+	// Parse optional return type
+	if p.peekTokenIs(token.COLON) {
+		returnType, ok := p.parseTypeAnnotation()
+		if !ok {
+			return false
+		}
+		lit.ReturnType = returnType
+	}
 
 	// Parse optional rating
 	if p.peekTokenIs(token.RATED) {
@@ -141,47 +389,57 @@ func (p *Parser) parseFunctionLiteral() Expression {
 
 	if !p.expectPeek(token.LBRACE) {
 		p.addError(errors.UNEXPECTED_TOKEN, "Expected '{' after function parameters")
-		return nil
+		return false
 	}
 
+	// Loops outside the function cannot be controlled from inside it
+	outerLoops, outerFunction := p.loops, p.function
+	p.loops, p.function = nil, lit
 	lit.Body = p.parseBlockStatement()
+	p.loops, p.function = outerLoops, outerFunction
 
-	return lit
+	return true
 }
 
-// Parse function parameters
-func (p *Parser) parseFunctionParameters() []*Identifier {
+// Parse function parameters and their optional type annotations
+func (p *Parser) parseFunctionParameters() ([]*Identifier, []string) {
 	identifiers := []*Identifier{}
+	types := []string{}
 
 	if p.peekTokenIs(token.RPAREN) {
 		p.nextToken()
-		return identifiers
-	}
-
-	p.nextToken()
-
-	ident := &Identifier{
-		Token: p.curToken,
-		Value: p.curToken.Literal,
+		return identifiers, types
 	}
-	identifiers = append(identifiers, ident)
 
-	for p.peekTokenIs(token.COMMA) {
-		p.nextToken()
+	for {
 		p.nextToken()
 		ident := &Identifier{
 			Token: p.curToken,
 			Value: p.curToken.Literal,
 		}
 		identifiers = append(identifiers, ident)
+
+		typeName := ""
+		if p.peekTokenIs(token.COLON) {
+			var ok bool
+			if typeName, ok = p.parseTypeAnnotation(); !ok {
+				return nil, nil
+			}
+		}
+		types = append(types, typeName)
+
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+		p.nextToken()
 	}
 
 	if !p.expectPeek(token.RPAREN) {
 		p.addError(errors.UNEXPECTED_TOKEN, "Expected ')'")
-		return nil
+		return nil, nil
 	}
 
-	return identifiers
+	return identifiers, types
 }
 
 // Parse a call expression
@@ -196,6 +454,31 @@ func (p *Parser) parseCallExpression(function Expression) Expression {
 	return exp
 }
 
+// Parse the rest of a slice expression once its start bound has been read,
+// with the colon as the peek token
+func (p *Parser) parseSliceExpression(bracket token.Token, left, start Expression) Expression {
+	exp := &SliceExpression{
+		Token: bracket,
+		Left:  left,
+		Start: start,
+	}
+
+	p.nextToken()
+
+	// The end may be omitted, e.g. "myArray[2:]"
+	if !p.peekTokenIs(token.RBRACKET) {
+		p.nextToken()
+		exp.End = p.parseExpression(LOWEST)
+	}
+
+	if !p.expectPeek(token.RBRACKET) {
+		p.addError(errors.UNEXPECTED_TOKEN, "Expected ']'")
+		return nil
+	}
+
+	return exp
+}
+
 // Parse an index expression
 func (p *Parser) parseIndexExpression(left Expression) Expression {
 	exp := &IndexExpression{
@@ -203,9 +486,18 @@ func (p *Parser) parseIndexExpression(left Expression) Expression {
 		Left:  left,
 	}
 
+	// A slice may omit its start, e.g. "myArray[:2]"
+	if p.peekTokenIs(token.COLON) {
+		return p.parseSliceExpression(exp.Token, left, nil)
+	}
+
 	p.nextToken()
 	exp.Index = p.parseExpression(LOWEST)
 
+	if p.peekTokenIs(token.COLON) {
+		return p.parseSliceExpression(exp.Token, left, exp.Index)
+	}
+
 	if !p.expectPeek(token.RBRACKET) {
 		p.addError(errors.UNEXPECTED_TOKEN, "Expected ']'")
 		return nil
@@ -4,6 +4,8 @@
 package parser
 
 import (
+	"fmt"
+
 	"github.com/AndrewDonelson/trumplang/internal/errors"
 	"github.com/AndrewDonelson/trumplang/internal/lexer/token"
 )
@@ -12,6 +14,9 @@ import (
 func (p *Parser) parseStatement() Statement {
 	switch p.curToken.Type {
 	case token.YUGE, token.TREMENDOUS:
+		if p.peekTokenIs(token.FUNCTION) {
+			return p.parseFunctionDeclaration()
+		}
 		return p.parseLetStatement()
 	case token.RETURN:
 		return p.parseReturnStatement()
@@ -30,6 +35,29 @@ func (p *Parser) parseStatement() Statement {
 		return p.parseRallyStatement()
 	case token.EXECUTIVE_ORDER:
 		return p.parseExecutiveOrderStatement()
+	case token.BIGLY:
+		p.addError(errors.SYNTAX_ERROR, "BIGLY must be the first statement in the file")
+		if p.peekTokenIs(token.SEMICOLON) {
+			p.nextToken()
+		}
+		return nil
+	case token.IMPORT:
+		return p.parseImportStatement()
+	case token.BORDER:
+		return p.parseExportStatement()
+	case token.FAKE_NEWS:
+		return p.parseThrowStatement()
+	case token.DENY:
+		return p.parseTryStatement()
+	case token.BREAK, token.CONTINUE:
+		return p.parseLoopControlStatement()
+	case token.YIELD:
+		return p.parseYieldStatement()
+	case token.IDENT:
+		if p.peekTokenIs(token.COLON) {
+			return p.parseLabeledStatement()
+		}
+		return p.parseExpressionStatement()
 	default:
 		return p.parseExpressionStatement()
 	}
@@ -49,6 +77,15 @@ func (p *Parser) parseLetStatement() *LetStatement {
 		Value: p.curToken.Literal,
 	}
 
+	// Parse the optional type annotation
+	if p.peekTokenIs(token.COLON) {
+		typeName, ok := p.parseTypeAnnotation()
+		if !ok {
+			return nil
+		}
+		stmt.Type = typeName
+	}
+
 	if !p.expectPeek(token.ASSIGN) {
 		p.addError(errors.UNEXPECTED_TOKEN, "Expected '=' after identifier")
 		return nil
@@ -65,6 +102,49 @@ func (p *Parser) parseLetStatement() *LetStatement {
 	return stmt
 }
 
+// Parse a type annotation with the colon as the peek token, e.g. ": INTEGER"
+func (p *Parser) parseTypeAnnotation() (string, bool) {
+	p.nextToken() // consume ':'
+	p.nextToken()
+
+	switch {
+	case p.curTokenIs(token.IDENT) && typeNames[p.curToken.Literal]:
+		return p.curToken.Literal, true
+	case p.curTokenIs(token.FUNCTION), p.curTokenIs(token.BILLIONS):
+		return p.curToken.Literal, true
+	}
+
+	p.addError(errors.SYNTAX_ERROR, fmt.Sprintf("Unknown type in annotation: %s", p.curToken.Literal))
+	return "", false
+}
+
+// Parse a named function declaration
+// e.g., "YUGE FUNCTION greet(name) RATED 10/10 { ... }"
+func (p *Parser) parseFunctionDeclaration() *FunctionDeclaration {
+	stmt := &FunctionDeclaration{Token: p.curToken}
+
+	p.nextToken() // move onto FUNCTION
+	lit := &FunctionLiteral{Token: p.curToken}
+
+	if !p.expectPeek(token.IDENT) {
+		p.addError(errors.EXPECTED_IDENTIFIER, "Expected function name after FUNCTION")
+		return nil
+	}
+
+	stmt.Name = &Identifier{
+		Token: p.curToken,
+		Value: p.curToken.Literal,
+	}
+	lit.Name = stmt.Name.Value
+
+	if !p.parseFunctionSignature(lit) {
+		return nil
+	}
+	stmt.Function = lit
+
+	return stmt
+}
+
 // Parse a return statement
 func (p *Parser) parseReturnStatement() *ReturnStatement {
 	stmt := &ReturnStatement{Token: p.curToken}
@@ -181,9 +261,25 @@ func (p *Parser) parseIfStatement() *IfStatement {
 	return stmt
 }
 
+// Parse a BUILD WALL IF used as a value
+// e.g., "YUGE size = BUILD WALL IF (n > 100) { "YUGE" } ELSE { "small" };"
+func (p *Parser) parseIfExpression() Expression {
+	stmt := p.parseIfStatement()
+	if stmt == nil {
+		return nil
+	}
+
+	return &IfExpression{
+		Token:       stmt.Token,
+		Condition:   stmt.Condition,
+		Consequence: stmt.Consequence,
+		Alternative: stmt.Alternative,
+	}
+}
+
 // Parse a while statement
 func (p *Parser) parseWhileStatement() *WhileStatement {
-	stmt := &WhileStatement{Token: p.curToken}
+	stmt := &WhileStatement{Token: p.curToken, Label: p.takeLoopLabel()}
 
 	// Expect MAKE DEALS WHILE
 	if !p.expectPeek(token.DEALS) {
@@ -216,7 +312,249 @@ func (p *Parser) parseWhileStatement() *WhileStatement {
 		return nil
 	}
 
+	p.enterLoop(stmt.Label)
+	stmt.Body = p.parseBlockStatement()
+	p.leaveLoop()
+
+	return stmt
+}
+
+// Parse the optional "BIGLY;" pragma that opens a file, which makes integer
+// arithmetic in the file promote to arbitrary precision instead of raising
+// INTEGER_OVERFLOW. Reports whether the pragma was present.
+func (p *Parser) parseBiglyPragma() bool {
+	if !p.curTokenIs(token.BIGLY) {
+		return false
+	}
+
+	p.bigly = true
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+	p.nextToken()
+
+	return true
+}
+
+// Parse an import statement
+// e.g., "IMPORT "utils.trump";" or "IMPORT "utils.trump" AS utils;"
+func (p *Parser) parseImportStatement() *ImportStatement {
+	stmt := &ImportStatement{Token: p.curToken}
+
+	if !p.expectPeek(token.STRING) {
+		p.addError(errors.UNEXPECTED_TOKEN, "Expected a file path after IMPORT")
+		return nil
+	}
+	stmt.Path = p.curToken.Literal
+
+	if p.peekTokenIs(token.AS) {
+		p.nextToken()
+
+		if !p.expectPeek(token.IDENT) {
+			p.addError(errors.EXPECTED_IDENTIFIER, "Expected identifier after AS")
+			return nil
+		}
+		stmt.Alias = &Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	}
+
+	// Allow optional semicolon
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+// Parse an exported declaration
+// e.g., "BORDER YUGE FUNCTION greet(name) { ... }"
+func (p *Parser) parseExportStatement() *ExportStatement {
+	stmt := &ExportStatement{Token: p.curToken}
+
+	if p.blockDepth > 0 {
+		p.addError(errors.SYNTAX_ERROR, "BORDER declarations are only allowed at the top level of a file")
+		return nil
+	}
+
+	if !p.peekTokenIs(token.YUGE) && !p.peekTokenIs(token.TREMENDOUS) {
+		p.addError(errors.UNEXPECTED_TOKEN, "Expected YUGE or TREMENDOUS after BORDER")
+		return nil
+	}
+
+	p.nextToken()
+
+	stmt.Declaration = p.parseStatement()
+
+	// The declaration may be a nil pointer if it failed to parse
+	switch decl := stmt.Declaration.(type) {
+	case *LetStatement:
+		if decl != nil {
+			stmt.Name = decl.Name
+		}
+	case *FunctionDeclaration:
+		if decl != nil {
+			stmt.Name = decl.Name
+		}
+	}
+
+	if stmt.Name == nil {
+		return nil
+	}
+
+	return stmt
+}
+
+// Parse a throw statement
+// e.g., "FAKE_NEWS "Something went wrong";"
+func (p *Parser) parseThrowStatement() *ThrowStatement {
+	stmt := &ThrowStatement{Token: p.curToken}
+
+	p.nextToken()
+
+	stmt.Value = p.parseExpression(LOWEST)
+
+	// Allow optional semicolon
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+// Parse a try statement
+// e.g., "DENY { ... } BLAME (err) { ... } ANYWAY { ... }"
+func (p *Parser) parseTryStatement() *TryStatement {
+	stmt := &TryStatement{Token: p.curToken}
+
+	if !p.expectPeek(token.LBRACE) {
+		p.addError(errors.UNEXPECTED_TOKEN, "Expected '{' after DENY")
+		return nil
+	}
+
 	stmt.Body = p.parseBlockStatement()
 
+	// Parse optional catch clause with an optional error binding
+	if p.peekTokenIs(token.BLAME) {
+		p.nextToken()
+
+		if p.peekTokenIs(token.LPAREN) {
+			p.nextToken()
+
+			if !p.expectPeek(token.IDENT) {
+				p.addError(errors.EXPECTED_IDENTIFIER, "Expected identifier after BLAME (")
+				return nil
+			}
+			stmt.CatchParam = &Identifier{Token: p.curToken, Value: p.curToken.Literal}
+
+			if !p.expectPeek(token.RPAREN) {
+				p.addError(errors.UNEXPECTED_TOKEN, "Expected ')' after BLAME identifier")
+				return nil
+			}
+		}
+
+		if !p.expectPeek(token.LBRACE) {
+			p.addError(errors.UNEXPECTED_TOKEN, "Expected '{' after BLAME")
+			return nil
+		}
+
+		stmt.Catch = p.parseBlockStatement()
+	}
+
+	// Parse optional finally clause
+	if p.peekTokenIs(token.ANYWAY) {
+		p.nextToken()
+
+		if !p.expectPeek(token.LBRACE) {
+			p.addError(errors.UNEXPECTED_TOKEN, "Expected '{' after ANYWAY")
+			return nil
+		}
+
+		stmt.Finally = p.parseBlockStatement()
+	}
+
+	if stmt.Catch == nil && stmt.Finally == nil {
+		p.addError(errors.SYNTAX_ERROR, "DENY must be followed by BLAME or ANYWAY")
+		return nil
+	}
+
 	return stmt
 }
+
+// Parse a labeled loop
+// e.g., "outer: MAKE DEALS WHILE (x < 10) { ... }"
+func (p *Parser) parseLabeledStatement() Statement {
+	label := p.curToken.Literal
+
+	p.nextToken() // consume the label
+	p.nextToken() // consume ':'
+
+	if !p.curTokenIs(token.MAKE) {
+		p.addError(errors.SYNTAX_ERROR, fmt.Sprintf("label %s must be followed by a loop", label))
+		return nil
+	}
+
+	p.loopLabel = label
+	stmt := p.parseStatement()
+	p.loopLabel = ""
+
+	return stmt
+}
+
+// Parse a yield statement, which makes the function it is in a generator
+func (p *Parser) parseYieldStatement() Statement {
+	stmt := &YieldStatement{Token: p.curToken}
+
+	if p.function == nil {
+		p.addError(errors.SYNTAX_ERROR, "YIELD used outside of a function")
+		return nil
+	}
+	p.function.Generator = true
+
+	p.nextToken()
+
+	stmt.Value = p.parseExpression(LOWEST)
+	if stmt.Value == nil {
+		return nil
+	}
+
+	// Allow optional semicolon
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+// Parse a YOU'RE FIRED (break) or NEXT DEAL (continue) statement with an
+// optional loop label
+func (p *Parser) parseLoopControlStatement() Statement {
+	tok := p.curToken
+
+	var label *Identifier
+	if p.peekTokenIs(token.IDENT) {
+		p.nextToken()
+		label = &Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	}
+
+	valid := true
+	if len(p.loops) == 0 {
+		p.addError(errors.SYNTAX_ERROR, fmt.Sprintf("%s used outside of a loop", tok.Literal))
+		valid = false
+	} else if label != nil && !p.inLoop(label.Value) {
+		p.addError(errors.SYNTAX_ERROR, fmt.Sprintf("%s refers to unknown loop label %s", tok.Literal, label.Value))
+		valid = false
+	}
+
+	// Allow optional semicolon
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	if !valid {
+		return nil
+	}
+
+	if tok.Type == token.BREAK {
+		return &BreakStatement{Token: tok, Label: label}
+	}
+	return &ContinueStatement{Token: tok, Label: label}
+}
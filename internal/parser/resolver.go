@@ -0,0 +1,304 @@
+// file: internal/parser/resolver.go
+// description: Declaration checks for constants and redeclarations in the TRUMP programming language
+
+package parser
+
+import (
+	"fmt"
+
+	"github.com/AndrewDonelson/trumplang/internal/errors"
+	"github.com/AndrewDonelson/trumplang/internal/lexer/token"
+)
+
+// declaration records where a name was declared and whether it is constant
+type declaration struct {
+	token    token.Token
+	constant bool
+}
+
+// scope holds the names declared in one scope of the running program. Like
+// the interpreter, blocks share the scope of the file, function or loop
+// they appear in. The branches of an IF are the exception: only one of them
+// runs, so each is checked in a branch scope of its own.
+type scope struct {
+	names  map[string]declaration
+	outer  *scope
+	branch bool
+}
+
+func newScope(outer *scope) *scope {
+	return &scope{names: make(map[string]declaration), outer: outer}
+}
+
+// Find the declaration a name refers to
+func (s *scope) lookup(name string) (declaration, bool) {
+	for ; s != nil; s = s.outer {
+		if decl, ok := s.names[name]; ok {
+			return decl, true
+		}
+	}
+	return declaration{}, false
+}
+
+// pendingFunction is a function body waiting to be checked
+type pendingFunction struct {
+	fn    *FunctionLiteral
+	scope *scope
+}
+
+// resolver checks the declarations of a parsed program, reporting names
+// declared twice in one scope and assignments to TREMENDOUS constants
+type resolver struct {
+	p       *Parser
+	scope   *scope
+	pending []pendingFunction
+}
+
+// Check the declarations of a program. Function bodies are checked after
+// the code around them, since they may run once all of it has.
+func (p *Parser) resolve(program *Program) {
+	r := &resolver{p: p, scope: newScope(nil)}
+	r.statements(program.Statements)
+
+	for len(r.pending) > 0 {
+		next := r.pending[0]
+		r.pending = r.pending[1:]
+
+		r.scope = newScope(next.scope)
+		for _, param := range next.fn.Parameters {
+			r.declare(param, false)
+		}
+		r.statements(next.fn.Body.Statements)
+	}
+}
+
+// Declare a name in the current scope, reporting it if already declared.
+// A branch scope shares the names of the scope around it.
+func (r *resolver) declare(name *Identifier, constant bool) {
+	for s := r.scope; s != nil; s = s.outer {
+		if previous, ok := s.names[name.Value]; ok {
+			r.p.addErrorAt(name.Token, errors.REDECLARATION, fmt.Sprintf("%s was already declared in this scope on line %d", name.Value, previous.token.Line))
+			return
+		}
+		if !s.branch {
+			break
+		}
+	}
+	r.scope.names[name.Value] = declaration{token: name.Token, constant: constant}
+}
+
+// Bind a name in the current scope without checking for an earlier one,
+// for names the interpreter rebinds freely such as pattern bindings
+func (r *resolver) bind(name *Identifier) {
+	r.scope.names[name.Value] = declaration{token: name.Token}
+}
+
+// Run a check in a new scope
+func (r *resolver) enclosed(check func()) {
+	outer := r.scope
+	r.scope = newScope(outer)
+	check()
+	r.scope = outer
+}
+
+// Check the branches of an IF, each in its own branch scope, then add the
+// names they declared to the current scope, since whichever branch runs
+// declares them there
+func (r *resolver) branches(blocks ...*BlockStatement) {
+	outer := r.scope
+	checked := make([]*scope, 0, len(blocks))
+	for _, block := range blocks {
+		r.scope = newScope(outer)
+		r.scope.branch = true
+		r.block(block)
+		checked = append(checked, r.scope)
+	}
+	r.scope = outer
+
+	for _, s := range checked {
+		for name, decl := range s.names {
+			if _, ok := outer.names[name]; !ok {
+				outer.names[name] = decl
+			}
+		}
+	}
+}
+
+func (r *resolver) statements(statements []Statement) {
+	for _, statement := range statements {
+		r.statement(statement)
+	}
+}
+
+func (r *resolver) block(block *BlockStatement) {
+	if block != nil {
+		r.statements(block.Statements)
+	}
+}
+
+func (r *resolver) statement(statement Statement) {
+	switch node := statement.(type) {
+	case *LetStatement:
+		r.expression(node.Value)
+		r.declare(node.Name, node.Constant())
+	case *FunctionDeclaration:
+		r.declare(node.Name, node.Constant())
+		r.expression(node.Function)
+	case *ExportStatement:
+		r.statement(node.Declaration)
+	case *ImportStatement:
+		if node.Alias != nil {
+			r.bind(node.Alias)
+		}
+	case *ExpressionStatement:
+		r.expression(node.Expression)
+	case *ReturnStatement:
+		r.expression(node.ReturnValue)
+	case *TweetStatement:
+		r.expression(node.Value)
+	case *RallyStatement:
+		r.expression(node.Value)
+	case *ExecutiveOrderStatement:
+		r.expression(node.Value)
+	case *ThrowStatement:
+		r.expression(node.Value)
+	case *YieldStatement:
+		r.expression(node.Value)
+	case *BlockStatement:
+		r.block(node)
+	case *IfStatement:
+		r.expression(node.Condition)
+		r.branches(node.Consequence, node.Alternative)
+	case *WhileStatement:
+		r.expression(node.Condition)
+		r.block(node.Body)
+	case *ForStatement:
+		r.enclosed(func() {
+			if node.Init != nil {
+				r.statement(node.Init)
+			}
+			r.expression(node.Condition)
+			if node.Update != nil {
+				r.statement(node.Update)
+			}
+			r.block(node.Body)
+		})
+	case *ForEachStatement:
+		r.expression(node.Iterable)
+		r.enclosed(func() {
+			if node.Key != nil {
+				r.bind(node.Key)
+			}
+			r.bind(node.Value)
+			r.block(node.Body)
+		})
+	case *TryStatement:
+		r.block(node.Body)
+		if node.CatchParam != nil {
+			r.enclosed(func() {
+				r.bind(node.CatchParam)
+				r.block(node.Catch)
+			})
+		} else {
+			r.block(node.Catch)
+		}
+		r.block(node.Finally)
+	}
+}
+
+func (r *resolver) expression(expression Expression) {
+	switch node := expression.(type) {
+	case *AssignExpression:
+		if name, ok := node.Target.(*Identifier); ok {
+			if decl, found := r.scope.lookup(name.Value); found && decl.constant {
+				r.p.addErrorAt(name.Token, errors.CONSTANT_ASSIGNMENT, fmt.Sprintf("cannot assign to %s, a TREMENDOUS constant declared on line %d", name.Value, decl.token.Line))
+			}
+		} else {
+			r.expression(node.Target)
+		}
+		r.expression(node.Value)
+	case *FunctionLiteral:
+		r.pending = append(r.pending, pendingFunction{fn: node, scope: r.scope})
+	case *IfExpression:
+		r.expression(node.Condition)
+		r.branches(node.Consequence, node.Alternative)
+	case *ConditionalExpression:
+		r.expression(node.Condition)
+		r.expression(node.Consequence)
+		r.expression(node.Alternative)
+	case *RangeExpression:
+		r.expression(node.Start)
+		r.expression(node.End)
+		r.expression(node.Step)
+	case *SpawnExpression:
+		r.expression(node.Call)
+	case *PrefixExpression:
+		r.expression(node.Right)
+	case *InfixExpression:
+		r.expression(node.Left)
+		r.expression(node.Right)
+	case *CallExpression:
+		r.expression(node.Function)
+		for _, arg := range node.Arguments {
+			r.expression(arg)
+		}
+	case *ArrayLiteral:
+		for _, el := range node.Elements {
+			r.expression(el)
+		}
+	case *HashLiteral:
+		for _, pair := range node.Pairs {
+			r.expression(pair.Key)
+			r.expression(pair.Value)
+		}
+	case *IndexExpression:
+		r.expression(node.Left)
+		r.expression(node.Index)
+	case *SliceExpression:
+		r.expression(node.Left)
+		r.expression(node.Start)
+		r.expression(node.End)
+	case *InterpolatedString:
+		for _, part := range node.Parts {
+			r.expression(part)
+		}
+	case *Placeholder:
+		r.expression(node.Value)
+	case *MatchExpression:
+		r.expression(node.Subject)
+		for _, arm := range node.Arms {
+			r.matchArm(arm)
+		}
+	}
+}
+
+// Check a NEGOTIATE arm in a scope holding its pattern bindings
+func (r *resolver) matchArm(arm *MatchArm) {
+	r.enclosed(func() {
+		for _, pattern := range arm.Patterns {
+			r.pattern(pattern)
+		}
+		r.expression(arm.Guard)
+		r.block(arm.Body)
+		r.expression(arm.Value)
+	})
+}
+
+func (r *resolver) pattern(pattern Pattern) {
+	switch node := pattern.(type) {
+	case *BindingPattern:
+		r.bind(node.Name)
+	case *ArrayPattern:
+		for _, el := range node.Elements {
+			r.pattern(el)
+		}
+		if node.Rest != nil {
+			r.bind(node.Rest)
+		}
+	case *LiteralPattern:
+		r.expression(node.Value)
+	case *RangePattern:
+		r.expression(node.Low)
+		r.expression(node.High)
+	}
+}